@@ -0,0 +1,237 @@
+// Package campaign provides a store for keeping generated content as part of a campaign record
+package campaign
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	dirPerm  = os.FileMode(0755)
+	filePerm = os.FileMode(0644)
+)
+
+// Entry is a single piece of stored content. Text and Markdown are rendered when the content is
+// saved so that entries can be shown and searched without knowing the type of their Data.
+type Entry struct {
+	ID       string
+	Type     string
+	Name     string
+	Created  time.Time
+	Text     string
+	Markdown string
+	Data     json.RawMessage
+}
+
+// Store is a campaign directory holding one JSON file per Entry
+type Store struct {
+	Dir string
+}
+
+// Open returns the Store in dir, creating the directory if it doesn't exist
+func Open(dir string) (*Store, error) {
+	if err := os.MkdirAll(dir, dirPerm); err != nil {
+		return nil, err
+	}
+
+	return &Store{Dir: dir}, nil
+}
+
+// Add stores content c of type t as a new Entry and returns it
+func (s *Store) Add(t, name, text, markdown string, c interface{}) (Entry, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	e := Entry{
+		Type:     t,
+		Name:     name,
+		Created:  time.Now(),
+		Text:     text,
+		Markdown: markdown,
+		Data:     data,
+	}
+
+	for e.ID == "" || s.exists(e.ID) {
+		if e.ID, err = newID(); err != nil {
+			return Entry{}, err
+		}
+	}
+
+	b, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return Entry{}, err
+	}
+
+	return e, ioutil.WriteFile(s.path(e.ID), b, filePerm)
+}
+
+// List returns every Entry, oldest first. If types are given only entries of those types are
+// returned.
+func (s *Store) List(types ...string) ([]Entry, error) {
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+
+		e, err := s.read(strings.TrimSuffix(f.Name(), ".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		if len(types) == 0 || match(e.Type, types) {
+			entries = append(entries, e)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Created.Before(entries[j].Created) })
+
+	return entries, nil
+}
+
+// Get returns the Entry with id. A unique prefix of an ID is enough to find it.
+func (s *Store) Get(id string) (Entry, error) {
+	if !validID(id) {
+		return Entry{}, fmt.Errorf("invalid id \"%s\", ids are up to %d hex characters", id, idLen)
+	}
+
+	if s.exists(id) {
+		return s.read(id)
+	}
+
+	entries, err := s.List()
+	if err != nil {
+		return Entry{}, err
+	}
+
+	var found []Entry
+	for _, e := range entries {
+		if strings.HasPrefix(e.ID, id) {
+			found = append(found, e)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return Entry{}, fmt.Errorf("no entry with id \"%s\"", id)
+	case 1:
+		return found[0], nil
+	default:
+		return Entry{}, fmt.Errorf("\"%s\" matches %d entries, use more of the id", id, len(found))
+	}
+}
+
+// Delete removes the Entry with id, or a unique prefix of it, and returns it
+func (s *Store) Delete(id string) (Entry, error) {
+	e, err := s.Get(id)
+	if err != nil {
+		return Entry{}, err
+	}
+
+	if !validID(e.ID) { // The ID is read from the entry's file so check it before using it as a path
+		return Entry{}, fmt.Errorf("entry has an invalid id \"%s\"", e.ID)
+	}
+
+	return e, os.Remove(s.path(e.ID))
+}
+
+// Search returns the entries whose name, type or text contain query, ignoring case. If types are
+// given only entries of those types are searched.
+func (s *Store) Search(query string, types ...string) ([]Entry, error) {
+	entries, err := s.List(types...)
+	if err != nil {
+		return nil, err
+	}
+
+	query = strings.ToLower(query)
+
+	var found []Entry
+	for _, e := range entries {
+		for _, field := range []string{e.Name, e.Type, e.Text} {
+			if strings.Contains(strings.ToLower(field), query) {
+				found = append(found, e)
+				break
+			}
+		}
+	}
+
+	return found, nil
+}
+
+func (s *Store) path(id string) string {
+	return filepath.Join(s.Dir, id+".json")
+}
+
+func (s *Store) exists(id string) bool {
+	_, err := os.Stat(s.path(id))
+	return err == nil
+}
+
+func (s *Store) read(id string) (Entry, error) {
+	var e Entry
+
+	b, err := ioutil.ReadFile(s.path(id))
+	if err != nil {
+		return e, err
+	}
+
+	if err := json.Unmarshal(b, &e); err != nil {
+		return e, fmt.Errorf("%s: %s", filepath.Base(s.path(id)), err)
+	}
+
+	return e, nil
+}
+
+// Length of entry IDs in hex characters
+const idLen = 8
+
+// newID returns a random 8 character hex ID. IDs come from crypto/rand rather than math/rand so
+// that saving content doesn't change the results of a seeded random source.
+func newID() (string, error) {
+	b := make([]byte, idLen/2)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// validID reports whether id is an ID, or a prefix of one, so that it is safe to use in a path
+func validID(id string) bool {
+	if id == "" || len(id) > idLen {
+		return false
+	}
+
+	for _, r := range id {
+		if !strings.ContainsRune("0123456789abcdef", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func match(t string, types []string) bool {
+	for _, x := range types {
+		if strings.ToLower(x) == strings.ToLower(t) {
+			return true
+		}
+	}
+
+	return false
+}
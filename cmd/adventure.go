@@ -35,6 +35,7 @@ var adventureCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		tag, _ := cmd.Flags().GetString(flTag)
 		list, _ := cmd.Flags().GetBool(flTags)
+		fmc, _ := cmd.Flags().GetString(flFormat)
 
 		if list {
 			for _, t := range content.Tags {
@@ -44,11 +45,10 @@ var adventureCmd = &cobra.Command{
 		}
 
 		if tag == "" {
-			tag = content.Tags.Random()
+			tag = content.Tags.Random(rng)
 		}
 
-		fmt.Fprintln(tw, content.NewAdventure(tag).String())
-		tw.Flush()
+		output(fmc, content.NewAdventure(rng, tag))
 	},
 }
 
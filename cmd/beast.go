@@ -21,11 +21,7 @@
 package cmd
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/nboughton/swnt/content"
-	"github.com/nboughton/swnt/content/format"
 	"github.com/spf13/cobra"
 )
 
@@ -37,18 +33,8 @@ var beastCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		fmc, _ := cmd.Flags().GetString(flFormat)
 
-		b := content.NewBeast()
-		for _, f := range strings.Split(fmc, ",") {
-			fID, err := format.Find(f)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-
-			fmt.Fprintf(tw, b.Format(fID))
-			fmt.Fprintln(tw)
-			tw.Flush()
-		}
+		b := content.NewBeast(rng)
+		output(fmc, b)
 	},
 }
 
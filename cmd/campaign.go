@@ -0,0 +1,203 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nboughton/swnt/campaign"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/spf13/cobra"
+)
+
+// campaignCmd represents the campaign command
+var campaignCmd = &cobra.Command{
+	Use:   "campaign",
+	Short: "List, show, search and delete content saved to the campaign",
+	Long: `Content generated by "new" sub-commands with --save is kept in the campaign directory
+(~/.config/swnt/campaign unless --campaign-dir is set). Each entry has an ID that is used to show
+or delete it, the first few characters of an ID are enough as long as they are unique.`,
+}
+
+var campaignListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List saved content",
+	Run: func(cmd *cobra.Command, args []string) {
+		store, types, ok := openCampaign(cmd)
+		if !ok {
+			return
+		}
+
+		entries, err := store.List(types...)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		listEntries(cmd, entries)
+	},
+}
+
+var campaignShowCmd = &cobra.Command{
+	Use:   "show [id]...",
+	Short: "Show saved content",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, _, ok := openCampaign(cmd)
+		if !ok {
+			return
+		}
+
+		fmc, _ := cmd.Flags().GetString(flFormat)
+		for _, id := range args {
+			e, err := store.Get(id)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			for _, f := range strings.Split(fmc, ",") {
+				fID, err := format.Find(f)
+				if err != nil {
+					fmt.Println(err)
+					return
+				}
+
+				switch fID {
+				case format.MARKDOWN:
+					fmt.Fprint(tw, e.Markdown)
+				case format.JSON:
+					tw.Write(e.Data)
+				default:
+					fmt.Fprint(tw, e.Text)
+				}
+
+				fmt.Fprintln(tw)
+				tw.Flush()
+			}
+		}
+	},
+}
+
+var campaignDeleteCmd = &cobra.Command{
+	Use:   "delete [id]...",
+	Short: "Delete saved content",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, _, ok := openCampaign(cmd)
+		if !ok {
+			return
+		}
+
+		for _, id := range args {
+			e, err := store.Delete(id)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Printf("Deleted %s \"%s\" (%s)\n", e.Type, e.Name, e.ID)
+		}
+	},
+}
+
+var campaignSearchCmd = &cobra.Command{
+	Use:   "search [text]",
+	Short: "Search the names and text of saved content",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		store, types, ok := openCampaign(cmd)
+		if !ok {
+			return
+		}
+
+		entries, err := store.Search(args[0], types...)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		listEntries(cmd, entries)
+	},
+}
+
+// openCampaign opens the campaign directory set for cmd and returns it with any types to filter by
+func openCampaign(cmd *cobra.Command) (*campaign.Store, []string, bool) {
+	dir, _ := cmd.Flags().GetString(flCampaignDir)
+	types, _ := cmd.Flags().GetStringSlice(flType)
+
+	store, err := campaign.Open(dir)
+	if err != nil {
+		fmt.Println("Error opening campaign:", err)
+		return nil, nil, false
+	}
+
+	return store, types, true
+}
+
+// listEntries writes a summary of entries in the formats set for cmd
+func listEntries(cmd *cobra.Command, entries []campaign.Entry) {
+	fmc, _ := cmd.Flags().GetString(flFormat)
+
+	if len(entries) == 0 {
+		fmt.Println("No saved content found")
+		return
+	}
+
+	var rows [][]string
+	for _, e := range entries {
+		rows = append(rows, []string{e.ID, e.Type, e.Name, e.Created.Format("2006-01-02 15:04")})
+	}
+
+	for _, f := range strings.Split(fmc, ",") {
+		fID, err := format.Find(f)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		if fID == format.JSON { // Summaries only, use show for the content itself
+			var list []map[string]interface{}
+			for _, e := range entries {
+				list = append(list, map[string]interface{}{"ID": e.ID, "Type": e.Type, "Name": e.Name, "Created": e.Created})
+			}
+
+			b, _ := json.MarshalIndent(list, "", "  ")
+			tw.Write(b)
+		} else {
+			fmt.Fprint(tw, format.Table(fID, []string{"ID", "Type", "Name", "Saved"}, rows))
+		}
+
+		fmt.Fprintln(tw)
+		tw.Flush()
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(campaignCmd)
+	campaignCmd.AddCommand(campaignListCmd, campaignShowCmd, campaignDeleteCmd, campaignSearchCmd)
+	campaignCmd.PersistentFlags().String(flCampaignDir, configPath("campaign"), "Set the campaign directory")
+	campaignCmd.PersistentFlags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md,json)")
+	campaignListCmd.Flags().StringSliceP(flType, "t", []string{}, "Only list content of these types (npc, world, sector etc)")
+	campaignSearchCmd.Flags().StringSliceP(flType, "t", []string{}, "Only search content of these types (npc, world, sector etc)")
+}
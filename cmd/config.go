@@ -0,0 +1,143 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v2"
+)
+
+// config is the format of ~/.config/swnt/config.yaml. Defaults and profiles map flag names (long
+// form, without dashes) to values, lists are used for flags that take more than one value:
+//
+//	profile: frontier
+//	defaults:
+//	  sector-height: 12
+//	profiles:
+//	  frontier:
+//	    exclude: [Regional Hegemon, Perimeter Agency]
+//	    other-worlds-chance: 5
+//
+// Profile is the profile used when --profile isn't set. Values from the profile override the
+// defaults, which override the values built into swnt, and flags set on the command line override
+// all of them. Flags that the command being run doesn't have are ignored.
+type config struct {
+	Profile  string                            `yaml:"profile"`
+	Defaults map[string]interface{}            `yaml:"defaults"`
+	Profiles map[string]map[string]interface{} `yaml:"profiles"`
+}
+
+// applyConfig reads the config file and sets any flags of cmd that haven't been set on the
+// command line. A missing config file is not an error unless a profile has been requested.
+func applyConfig(cmd *cobra.Command) error {
+	profile, _ := cmd.Flags().GetString(flProfile)
+
+	b, err := ioutil.ReadFile(configPath("config.yaml"))
+	if os.IsNotExist(err) && profile == "" {
+		return nil
+	} else if os.IsNotExist(err) {
+		return fmt.Errorf("profile \"%s\" requested but there is no config file at %s", profile, configPath("config.yaml"))
+	} else if err != nil {
+		return err
+	}
+
+	var c config
+	if err := yaml.Unmarshal(b, &c); err != nil {
+		return fmt.Errorf("%s: %s", configPath("config.yaml"), err)
+	}
+
+	if profile == "" {
+		profile = c.Profile
+	}
+
+	values := make(map[string]interface{})
+	for k, v := range c.Defaults {
+		values[k] = v
+	}
+
+	if profile != "" {
+		p, ok := c.Profiles[profile]
+		if !ok {
+			return fmt.Errorf("no profile \"%s\" in config, profiles available are %s", profile, c.profileNames())
+		}
+
+		for k, v := range p {
+			values[k] = v
+		}
+	}
+
+	for name, v := range values {
+		f := cmd.Flags().Lookup(name)
+		if f == nil || f.Changed || name == flProfile {
+			continue
+		}
+
+		if err := setFlag(cmd.Flags(), f, v); err != nil {
+			return fmt.Errorf("config value for %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// setFlag sets flag f to the value v read from the config file
+func setFlag(flags *pflag.FlagSet, f *pflag.Flag, v interface{}) error {
+	list, isList := v.([]interface{})
+	if !isList {
+		return flags.Set(f.Name, fmt.Sprint(v))
+	}
+
+	// Array flags take one value at a time, slice flags take comma separated values
+	if strings.HasSuffix(f.Value.Type(), "Array") {
+		for _, item := range list {
+			if err := flags.Set(f.Name, fmt.Sprint(item)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var items []string
+	for _, item := range list {
+		items = append(items, fmt.Sprint(item))
+	}
+
+	return flags.Set(f.Name, strings.Join(items, ","))
+}
+
+// profileNames returns the names of the profiles in c
+func (c config) profileNames() []string {
+	var names []string
+	for n := range c.Profiles {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -34,7 +34,7 @@ var cultureCmd = &cobra.Command{
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		fmt.Fprintf(tw, culture.Random().String())
+		fmt.Fprintf(tw, culture.Random(rng).String())
 		tw.Flush()
 	},
 }
@@ -21,11 +21,7 @@
 package cmd
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/nboughton/swnt/content"
-	"github.com/nboughton/swnt/content/format"
 	"github.com/spf13/cobra"
 )
 
@@ -38,18 +34,8 @@ var encounterCmd = &cobra.Command{
 		wild, _ := cmd.Flags().GetBool(flWilderness)
 		fmc, _ := cmd.Flags().GetString(flFormat)
 
-		e := content.NewEncounter(wild)
-		for _, f := range strings.Split(fmc, ",") {
-			fID, err := format.Find(f)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-
-			fmt.Fprintf(tw, e.Format(fID))
-			fmt.Fprintln(tw)
-			tw.Flush()
-		}
+		e := content.NewEncounter(rng, wild)
+		output(fmc, e)
 	},
 }
 
@@ -22,40 +22,77 @@ package cmd
 
 import (
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/nboughton/go-utils/json/file"
 	"github.com/nboughton/swnt/content/sector"
 	"github.com/nboughton/swnt/export"
+	"github.com/nboughton/swnt/hexmap"
 	"github.com/spf13/cobra"
 )
 
 // exportCmd represents the export command
 var exportCmd = &cobra.Command{
 	Use:   "export",
-	Short: "Export a json dump to hugo or text",
+	Short: "Export a json dump to hugo, text or any other export format",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
 		jsonFile, _ := cmd.Flags().GetString(flFile)
 		exportTypes, _ := cmd.Flags().GetString(flExport)
+		mapSize, _ := cmd.Flags().GetInt(flMapSize)
+		hugoTheme, _ := cmd.Flags().GetString(flHugoTheme)
+		importFormat, _ := cmd.Flags().GetString(flImportFormat)
 
-		secName := strings.Replace(jsonFile, ".json", "", -1)
+		if mapSize < hexmap.MinPixelSize {
+			fmt.Printf("Map size must be at least %d pixels\n", hexmap.MinPixelSize)
+			return
+		}
+		export.MapPixelSize = mapSize
+		export.HugoTheme = hugoTheme
+
+		var (
+			secName = strings.Replace(jsonFile, ".json", "", -1)
+			secData = new(sector.Stars)
+		)
+
+		switch importFormat {
+		case "swnt":
+			if err := file.Scan(jsonFile, &secData); err != nil {
+				fmt.Println("Error reading file. You may need to reformat the JSON data to make it more easily readable.", err)
+				return
+			}
+
+		case "swn":
+			name, stars, err := export.ImportSWN(jsonFile)
+			if err != nil {
+				fmt.Println("Error reading sectors without number file:", err)
+				return
+			}
+			secName, secData = name, stars
+
+		case "sec":
+			name, stars, err := export.ImportSEC(jsonFile)
+			if err != nil {
+				fmt.Println("Error reading sec file:", err)
+				return
+			}
+			secName, secData = name, stars
 
-		secData := new(sector.Stars)
-		if err := file.Scan(jsonFile, &secData); err != nil {
-			fmt.Println("Error reading file. You may need to reformat the JSON data to make it more easily readable.", err)
+		default:
+			fmt.Printf("Unknown import format \"%s\", options are swnt, swn and sec\n", importFormat)
 			return
 		}
 
 		for _, t := range strings.Split(exportTypes, ",") {
 			if exporter, err := export.New(t, secName, secData); exporter != nil {
 				if err != nil {
-					log.Fatal(err)
+					fmt.Println(err)
+					return
 				}
 
 				if err = exporter.Write(); err != nil {
-					log.Fatal(err)
+					fmt.Println(err)
+					return
 				}
 			}
 		}
@@ -64,6 +101,9 @@ var exportCmd = &cobra.Command{
 
 func init() {
 	RootCmd.AddCommand(exportCmd)
-	exportCmd.Flags().StringP(flFile, "i", "", "Path to json file")
+	exportCmd.Flags().StringP(flFile, "i", "", "Path to json (or sec) file")
 	exportCmd.Flags().StringP(flExport, "x", "hugo,txt", "Set export format")
+	exportCmd.Flags().String(flImportFormat, "swnt", "Set the format of the json file. Use swnt for files exported by swnt swn for files exported by sectorswithoutnumber.com or sec for Traveller style tab delimited files")
+	exportCmd.Flags().Int(flMapSize, export.MapPixelSize, "Set the radius in pixels of hexes in PNG maps")
+	exportCmd.Flags().String(flHugoTheme, "", "Set the theme used by hugo exports. The theme must be installed in the site's themes directory, without one the site uses its own minimal layouts")
 }
@@ -0,0 +1,228 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/nboughton/go-utils/json/file"
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/spf13/cobra"
+)
+
+// newFactionCmd represents the new faction command
+var newFactionCmd = &cobra.Command{
+	Use:   "faction",
+	Short: "Generate a Faction",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			fmc, _        = cmd.Flags().GetString(flFormat)
+			size, _       = cmd.Flags().GetString(flSize)
+			homeworld, _  = cmd.Flags().GetString(flHomeworld)
+			rosterFile, _ = cmd.Flags().GetString(flRoster)
+		)
+
+		s, err := content.FindFactionSize(rng, size)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		roster := new(content.FactionRoster)
+		if _, err := os.Stat(rosterFile); rosterFile != "" && err == nil {
+			if err := file.Scan(rosterFile, &roster); err != nil {
+				fmt.Println("Error reading roster file.", err)
+				return
+			}
+
+			if err := roster.Validate(); err != nil {
+				fmt.Println("Error reading roster file.", err)
+				return
+			}
+		}
+
+		f := content.NewFaction(rng, s, homeworld)
+		for roster.Find(f.Name) >= 0 { // Keep faction names unique within a roster so that turn logs are unambiguous
+			f = content.NewFaction(rng, s, homeworld)
+		}
+
+		output(fmc, f)
+
+		if rosterFile == "" {
+			return
+		}
+
+		roster.Factions = append(roster.Factions, f)
+		if err := file.Write(rosterFile, roster); err != nil {
+			fmt.Println(err)
+			return
+		}
+		fmt.Printf("Added %s to %s\n", f.Name, rosterFile)
+	},
+}
+
+// factionCmd represents the faction command
+var factionCmd = &cobra.Command{
+	Use:   "faction",
+	Short: "Manage a roster of Factions",
+	Long:  ``,
+}
+
+// factionTurnCmd represents the faction turn command
+var factionTurnCmd = &cobra.Command{
+	Use:   "turn",
+	Short: "Run faction turns for a roster of Factions and log the outcome",
+	Long: `Each turn every faction, in a random order, earns its income, takes a single action
+(attacking a rival, buying an asset or repairing damage) and checks whether it has completed
+its goal. The updated roster is written back to the roster file.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			rosterFile, _ = cmd.Flags().GetString(flFile)
+			turns, _      = cmd.Flags().GetInt(flTurns)
+			fmc, _        = cmd.Flags().GetString(flFormat)
+		)
+
+		if turns < 1 {
+			fmt.Println("Turns must be at least 1")
+			return
+		}
+
+		roster := new(content.FactionRoster)
+		if err := file.Scan(rosterFile, &roster); err != nil {
+			fmt.Println("Error reading roster file.", err)
+			return
+		}
+
+		if err := roster.Validate(); err != nil {
+			fmt.Println("Error reading roster file.", err)
+			return
+		}
+
+		if len(roster.Factions) == 0 {
+			fmt.Println("There are no factions in", rosterFile)
+			return
+		}
+
+		var log []string
+		for i := 0; i < turns && len(roster.Factions) > 0; i++ {
+			log = append(log, roster.RunTurn(rng)...)
+		}
+
+		for _, fm := range strings.Split(fmc, ",") {
+			fID, err := format.Find(fm)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			if fID == format.JSON {
+				b, err := json.MarshalIndent(factionTurn{Log: log, Factions: roster.Factions}, "", "  ")
+				if err != nil {
+					fmt.Println(err)
+					return
+				}
+
+				tw.Write(b)
+				fmt.Fprintln(tw)
+				tw.Flush()
+				continue
+			}
+
+			fmt.Fprint(tw, formatTurnLog(fID, log))
+			fmt.Fprintln(tw)
+
+			for _, f := range roster.Factions {
+				fmt.Fprintf(tw, f.Format(fID))
+				fmt.Fprintln(tw)
+			}
+			tw.Flush()
+		}
+
+		if err := file.Write(rosterFile, roster); err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// factionTurn is the JSON output of faction turn
+type factionTurn struct {
+	Log      []string          `json:"log"`
+	Factions []content.Faction `json:"factions"`
+}
+
+// formatTurnLog returns the log of faction turns in format t with a header for each turn
+func formatTurnLog(t format.OutputType, log []string) string {
+	var (
+		buf  = new(bytes.Buffer)
+		list bool // An HTML list is open
+	)
+
+	for _, l := range log {
+		if strings.HasPrefix(l, "Turn ") {
+			if list {
+				fmt.Fprintln(buf, "</ul>")
+				list = false
+			}
+
+			fmt.Fprint(buf, format.Header(t, 2, l))
+			continue
+		}
+
+		switch t {
+		case format.MARKDOWN:
+			fmt.Fprintln(buf, "- "+l)
+		case format.HTML:
+			if !list {
+				fmt.Fprintln(buf, "<ul>")
+				list = true
+			}
+			fmt.Fprintf(buf, "<li>%s</li>\n", html.EscapeString(l))
+		default:
+			fmt.Fprintln(buf, l)
+		}
+	}
+
+	if list {
+		fmt.Fprintln(buf, "</ul>")
+	}
+
+	return buf.String()
+}
+
+func init() {
+	newCmd.AddCommand(newFactionCmd)
+	newFactionCmd.Flags().StringP(flSize, "s", "", fmt.Sprintf("Set the faction size (%s). Random if not set", content.FactionSizes))
+	newFactionCmd.Flags().String(flHomeworld, "", "Set the faction's homeworld. Random if not set")
+	newFactionCmd.Flags().StringP(flRoster, "r", "", "Append the faction to a roster file for use with \"faction turn\", creating it if it doesn't exist")
+
+	RootCmd.AddCommand(factionCmd)
+	factionCmd.AddCommand(factionTurnCmd)
+	factionTurnCmd.Flags().StringP(flFile, "i", "factions.json", "Faction roster file to load and update")
+	factionTurnCmd.Flags().IntP(flTurns, "t", 1, "Set the number of turns to run")
+	factionTurnCmd.Flags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md,json,html)")
+}
@@ -21,9 +21,15 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"reflect"
+	"strings"
 	"text/tabwriter"
 
+	"github.com/nboughton/swnt/campaign"
+	"github.com/nboughton/swnt/content/format"
 	"github.com/spf13/cobra"
 )
 
@@ -35,9 +41,119 @@ var newCmd = &cobra.Command{
 	Use:   "new",
 	Short: "Generate content",
 	Long:  ``,
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if save, _ := cmd.Flags().GetBool(flSave); !save || len(generated) == 0 {
+			return
+		}
+
+		dir, _ := cmd.Flags().GetString(flCampaignDir)
+		store, err := campaign.Open(dir)
+		if err != nil {
+			fmt.Println("Error opening campaign:", err)
+			return
+		}
+
+		for _, g := range generated {
+			text, md := fmt.Sprint(g.content), fmt.Sprint(g.content)
+			if f, ok := g.content.(formatter); ok {
+				text, md = f.Format(format.TEXT), f.Format(format.MARKDOWN)
+			}
+
+			e, err := store.Add(cmd.Name(), g.name, text, md, g.content)
+			if err != nil {
+				fmt.Println("Error saving to campaign:", err)
+				return
+			}
+			fmt.Printf("Saved %s \"%s\" to campaign as %s\n", e.Type, e.Name, e.ID)
+		}
+	},
+}
+
+// generatedContent is content kept by a command for saving with --save, or by the shell
+type generatedContent struct {
+	name    string
+	content interface{}
+}
+
+// generated holds the content generated by the current command. The shell resets it before each
+// command that it runs and keeps it for rerolling and saving.
+var generated []generatedContent
+
+// keep records content c, called name, for saving to the campaign and for the shell. If name is
+// empty it is taken from c's Name field or, failing that, the first line of its text.
+func keep(name string, c interface{}) {
+	if name == "" {
+		name = contentName(c)
+	}
+
+	generated = append(generated, generatedContent{name: name, content: c})
+}
+
+// contentName returns a name for content c
+func contentName(c interface{}) string {
+	v := reflect.Indirect(reflect.ValueOf(c))
+	if v.Kind() == reflect.Struct {
+		if f := v.FieldByName("Name"); f.IsValid() && f.Kind() == reflect.String && f.String() != "" {
+			return f.String()
+		}
+	}
+
+	for _, l := range strings.Split(fmt.Sprint(c), "\n") {
+		if l = strings.TrimSpace(strings.TrimRight(strings.TrimSpace(l), ":")); l != "" {
+			return l
+		}
+	}
+
+	return "Untitled"
+}
+
+// formatter is implemented by content that can be rendered in any of the text output formats
+type formatter interface {
+	Format(t format.OutputType) string
+}
+
+// output writes content c to tw in each of the comma separated formats in fmc. The json format
+// writes the structure of c itself so that it can be read by other tools, other formats use
+// c's Format method if it has one. c is kept for saving to the campaign with --save, and by the
+// shell.
+func output(fmc string, c interface{}) {
+	keep("", c)
+
+	for _, f := range strings.Split(fmc, ",") {
+		fID, err := format.Find(f)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		s, err := render(fID, c)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		fmt.Fprintln(tw, s)
+		tw.Flush()
+	}
+}
+
+// render returns content c in format t as output writes it
+func render(t format.OutputType, c interface{}) (string, error) {
+	if t == format.JSON {
+		b, err := json.MarshalIndent(c, "", "  ")
+		return string(b), err
+	}
+
+	if v, ok := c.(formatter); ok {
+		return v.Format(t), nil
+	}
+
+	return fmt.Sprint(c), nil
 }
 
 func init() {
 	RootCmd.AddCommand(newCmd)
-	newCmd.PersistentFlags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md). Not all commands support this flag.")
+	newCmd.PersistentFlags().Bool(flSave, false, "Save the generated content to the campaign (see swnt campaign)")
+	newCmd.PersistentFlags().String(flCampaignDir, configPath("campaign"), "Set the campaign directory that content is saved to")
+	newCmd.PersistentFlags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md,json). Not all commands support this flag.")
 }
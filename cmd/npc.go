@@ -22,13 +22,9 @@ package cmd
 
 import (
 	"fmt"
-	"math/rand"
-	"strings"
-	"time"
 
 	"github.com/nboughton/swnt/content"
 	"github.com/nboughton/swnt/content/culture"
-	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/gender"
 	"github.com/spf13/cobra"
 )
@@ -47,36 +43,24 @@ var npcCmd = &cobra.Command{
 			err         error
 		)
 
-		cID, err := culture.Find(clt)
+		cID, err := culture.Find(rng, clt)
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
 
-		gID, err := gender.Find(gdr)
+		gID, err := gender.Find(rng, gdr)
 		if err != nil {
 			fmt.Println(err)
 			return
 		}
 
-		n := content.NewNPC(cID, gID, isPatron)
-		for _, f := range strings.Split(fmc, ",") {
-			fID, err := format.Find(f)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-
-			fmt.Fprintf(tw, n.Format(fID))
-			fmt.Fprintln(tw)
-			tw.Flush()
-		}
+		n := content.NewNPC(rng, cID, gID, isPatron)
+		output(fmc, n)
 	},
 }
 
 func init() {
-	rand.Seed(time.Now().UnixNano())
-
 	newCmd.AddCommand(npcCmd)
 	npcCmd.Flags().StringP(flCulture, "c", "any", fmt.Sprintf("Select Culture, choices are: %v", culture.Cultures))
 	npcCmd.Flags().StringP(flGender, "g", "", fmt.Sprintf("Select Gender, choices are: %v", gender.Genders))
@@ -33,7 +33,7 @@ var reactCmd = &cobra.Command{
 	Short: "Make a reaction roll for an NPC",
 	Long:  ``,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(content.Reaction.Roll())
+		fmt.Println(rng.Roll(content.Reaction))
 	},
 }
 
@@ -0,0 +1,137 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/table"
+	"github.com/spf13/cobra"
+)
+
+// rollCmd represents the roll command
+var rollCmd = &cobra.Command{
+	Use:   "roll [table id]...",
+	Short: "Roll on any registered table, built-in or user defined",
+	Long: `Roll on any registered table, built-in or user defined. User tables are read from YAML or
+JSON files in ~/.config/swnt/tables, each file holding one table or a list of them:
+
+  id: homebrew.Weather
+  name: Weather
+  dice: 2d6
+  items:
+    - match: 2-4
+      text: Storms
+    - match: 5-12
+      text: Clear skies
+
+A table can instead have a "list" of items that are equally likely. User tables with the same id
+as a built-in table replace it for the roll command only, generators such as "new world" always
+use the built-in tables.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			list, _  = cmd.Flags().GetBool(flList)
+			count, _ = cmd.Flags().GetInt(flCount)
+			fmc, _   = cmd.Flags().GetString(flFormat)
+		)
+
+		var ids []string
+		for id := range table.Registry {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		if list || len(args) == 0 {
+			var rows [][]string
+			for _, id := range ids {
+				rows = append(rows, []string{id, table.Registry[id].Name})
+			}
+
+			for _, f := range strings.Split(fmc, ",") {
+				fID, err := format.Find(f)
+				if err != nil {
+					fmt.Println(err)
+					return
+				}
+
+				fmt.Fprintf(tw, format.Table(fID, []string{"Table", "Name"}, rows))
+				fmt.Fprintln(tw)
+				tw.Flush()
+			}
+			return
+		}
+
+		var tables []roll.Table
+		for _, a := range args {
+			t, err := findTable(ids, a)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			tables = append(tables, t)
+		}
+
+		var res rollResults
+		for _, t := range tables {
+			for i := 0; i < count; i++ {
+				res = append(res, []string{t.Name, rng.Table(t)})
+			}
+		}
+
+		output(fmc, res)
+	},
+}
+
+// rollResults are the table and result of each roll
+type rollResults [][]string
+
+// Format returns the results as a table formatted as type t
+func (r rollResults) Format(t format.OutputType) string {
+	return format.Table(t, []string{"Table", "Result"}, r)
+}
+
+// MarshalJSON satisfies json.Marshaler, the results are written as the json format table
+func (r rollResults) MarshalJSON() ([]byte, error) {
+	return []byte(r.Format(format.JSON)), nil
+}
+
+// findTable returns the registered table with id n. The search is case insensitive for convenience
+func findTable(ids []string, n string) (roll.Table, error) {
+	for _, id := range ids {
+		if strings.ToLower(id) == strings.ToLower(n) {
+			return table.Registry[id], nil
+		}
+	}
+
+	return roll.Table{}, fmt.Errorf("no table registered with id \"%s\", use --list to see the available tables", n)
+}
+
+func init() {
+	RootCmd.AddCommand(rollCmd)
+	rollCmd.Flags().BoolP(flList, "l", false, "List the registered tables")
+	rollCmd.Flags().IntP(flCount, "c", 1, "Set the number of times to roll on each table")
+	rollCmd.Flags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md)")
+}
@@ -23,7 +23,12 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 	"github.com/spf13/cobra"
 )
 
@@ -38,7 +43,8 @@ const (
 	flExport    = "export"
 	flDensity   = "density"
 
-	flFile = "file"
+	flFile         = "file"
+	flImportFormat = "import-format"
 
 	flWilderness = "wilderness"
 
@@ -55,13 +61,125 @@ const (
 	flName   = "name"
 	flFilter = "filter"
 	flAll    = "all"
+
+	flSeed = "seed"
+
+	flYes       = "yes"
+	flBatch     = "batch"
+	flOutputDir = "output-dir"
+	flCount     = "count"
+	flMapSize   = "map-size"
+	flHugoTheme = "hugo-theme"
+	flLanes     = "lanes"
+	flFactions  = "factions"
+
+	flFrom  = "from"
+	flTo    = "to"
+	flDrive = "drive"
+
+	flSize      = "size"
+	flHomeworld = "homeworld"
+	flRoster    = "roster"
+	flTurns     = "turns"
+
+	flClass = "class"
+
+	flTagPack       = "tag-pack"
+	flNoBuiltinTags = "no-builtin-tags"
+
+	flProfile = "profile"
+
+	flAddr = "addr"
+
+	flSave        = "save"
+	flCampaignDir = "campaign-dir"
+	flType        = "type"
+
+	flStar  = "star"
+	flRow   = "row"
+	flCol   = "col"
+	flWorld = "world"
+	flPOI   = "poi"
+
+	flTL         = "tl"
+	flMinTL      = "min-tl"
+	flAtmosphere = "atmosphere"
+	flBiosphere  = "biosphere"
+	flPopulation = "population"
 )
 
+// rng is the random source that every command draws from, --seed sets its seed
+var rng = dice.New(time.Now().UnixNano())
+
 // RootCmd represents the base command when called without any subcommands
 var RootCmd = &cobra.Command{
 	Use:   "swnt",
 	Short: "A simple application for generating content for Stars Without Number",
 	Long:  ``,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// Config is applied first so that it can set any other flag, including the seed
+		if err := applyConfig(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return fmt.Errorf("error reading config: %s", err)
+		}
+
+		if cmd.Flags().Changed(flSeed) {
+			seed, _ := cmd.Flags().GetInt64(flSeed)
+			rng.Seed(seed)
+		}
+
+		if _, err := table.LoadDir(configPath("tables")); err != nil {
+			fmt.Fprintln(os.Stderr, "Error loading user tables:", err)
+		}
+
+		// A broken tag pack in the config directory shouldn't stop every command, but tags asked for
+		// on the command line or in a profile must load
+		if err := loadTags(cmd); err != nil {
+			if cmd.Flags().Changed(flTagPack) || cmd.Flags().Changed(flNoBuiltinTags) {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("error loading tag packs: %s", err)
+			}
+
+			fmt.Fprintln(os.Stderr, "Error loading tag packs:", err)
+		}
+
+		return nil
+	},
+}
+
+// loadTags merges the tag packs in ~/.config/swnt/tags and any set with --tag-pack into the world
+// tags in use
+func loadTags(cmd *cobra.Command) error {
+	var (
+		packs, _     = cmd.Flags().GetStringArray(flTagPack)
+		noBuiltin, _ = cmd.Flags().GetBool(flNoBuiltinTags)
+		tags         content.TagsTable
+	)
+
+	if _, err := os.Stat(configPath("tags")); err == nil {
+		packs = append([]string{configPath("tags")}, packs...)
+	}
+
+	for _, p := range packs {
+		t, err := content.LoadTagPack(p)
+		if err != nil {
+			return err
+		}
+
+		tags = append(tags, t...)
+	}
+
+	return content.UseTags(tags, !noBuiltin)
+}
+
+// configPath returns the path of elem within the swnt config directory (~/.config/swnt)
+func configPath(elem ...string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = "."
+	}
+
+	return filepath.Join(append([]string{home, ".config", "swnt"}, elem...)...)
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -72,3 +190,10 @@ func Execute() {
 		os.Exit(1)
 	}
 }
+
+func init() {
+	RootCmd.PersistentFlags().StringArray(flTagPack, []string{}, "Load world tags from a YAML or JSON file or directory, in addition to those in ~/.config/swnt/tags")
+	RootCmd.PersistentFlags().Bool(flNoBuiltinTags, false, "Use only the world tags from tag packs")
+	RootCmd.PersistentFlags().String(flProfile, "", "Use the named profile from ~/.config/swnt/config.yaml to set default flag values")
+	RootCmd.PersistentFlags().Int64(flSeed, 0, "Seed the random source so that generated content can be reproduced (defaults to a time based seed)")
+}
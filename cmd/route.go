@@ -0,0 +1,107 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nboughton/go-utils/json/file"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/spf13/cobra"
+)
+
+// routeCmd represents the route command
+var routeCmd = &cobra.Command{
+	Use:   "route",
+	Short: "Find the quickest spike drive route between two systems in a sector",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			jsonFile, _ = cmd.Flags().GetString(flFile)
+			from, _     = cmd.Flags().GetString(flFrom)
+			to, _       = cmd.Flags().GetString(flTo)
+			drive, _    = cmd.Flags().GetInt(flDrive)
+			fmc, _      = cmd.Flags().GetString(flFormat)
+		)
+
+		secData := new(sector.Stars)
+		if err := file.Scan(jsonFile, &secData); err != nil {
+			fmt.Println("Error reading file. You may need to reformat the JSON data to make it more easily readable.", err)
+			return
+		}
+
+		jumps, err := secData.Route(from, to, drive)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		if len(jumps) == 0 {
+			fmt.Println("Origin and destination are the same system")
+			return
+		}
+
+		var (
+			rows  [][]string
+			days  float64
+			hexes int
+		)
+
+		for i, j := range jumps {
+			rows = append(rows, []string{
+				strconv.Itoa(i + 1),
+				fmt.Sprintf("%s (%s)", j.From.Name, j.From.Hex()),
+				fmt.Sprintf("%s (%s)", j.To.Name, j.To.Hex()),
+				strconv.Itoa(j.Hexes),
+				strconv.FormatFloat(j.Days, 'f', -1, 64),
+				strconv.Itoa(j.Difficulty),
+			})
+			days += j.Days
+			hexes += j.Hexes
+		}
+		rows = append(rows, []string{"Total", "", "", strconv.Itoa(hexes), strconv.FormatFloat(days, 'f', -1, 64), ""})
+
+		for _, f := range strings.Split(fmc, ",") {
+			fID, err := format.Find(f)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fmt.Fprintf(tw, format.Header(fID, 2, fmt.Sprintf("%s to %s, Drive-%d", jumps[0].From.Name, jumps[len(jumps)-1].To.Name, drive)))
+			fmt.Fprintf(tw, format.Table(fID, []string{"Jump", "From", "To", "Hexes", "Days", "Drill Difficulty"}, rows))
+			fmt.Fprintln(tw)
+			tw.Flush()
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(routeCmd)
+	routeCmd.Flags().StringP(flFile, "i", "", "Path to sector json file")
+	routeCmd.Flags().String(flFrom, "", "Name of the system to start from")
+	routeCmd.Flags().String(flTo, "", "Name of the destination system")
+	routeCmd.Flags().IntP(flDrive, "d", 1, "Spike drive rating of the ship")
+	routeCmd.Flags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md)")
+}
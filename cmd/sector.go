@@ -21,15 +21,21 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"log"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/name"
 	"github.com/nboughton/swnt/content/sector"
 	"github.com/nboughton/swnt/export"
+	"github.com/nboughton/swnt/hexmap"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -53,81 +59,202 @@ var sectorCmd = &cobra.Command{
 			secWidth, _         = cmd.Flags().GetInt(flSecWidth)
 			exportTypes, _      = cmd.Flags().GetString(flExport)
 			density, _          = cmd.Flags().GetString(flDensity)
+			batch, _            = cmd.Flags().GetBool(flYes)
+			outputDir, _        = cmd.Flags().GetString(flOutputDir)
+			nameOverride, _     = cmd.Flags().GetString(flName)
+			count, _            = cmd.Flags().GetInt(flCount)
+			mapSize, _          = cmd.Flags().GetInt(flMapSize)
+			hugoTheme, _        = cmd.Flags().GetString(flHugoTheme)
+			lanes, _            = cmd.Flags().GetBool(flLanes)
+			factions, _         = cmd.Flags().GetBool(flFactions)
 		)
 
-		dVal := sector.AVERAGE
-		switch density {
-		case "sparse":
-			dVal = sector.SPARSE
-		case "average":
-			dVal = sector.AVERAGE
-		case "dense":
-			dVal = sector.DENSE
-		default:
-			fmt.Printf("Unknown density value [%s], use sparse, average or dense\n", density)
+		dVal, err := sector.ParseDensity(density)
+		if err != nil {
+			fmt.Println(err)
 			return
 		}
 
-		if secHeight < 2 || secHeight > 99 || secWidth < 2 || secWidth > 99 {
-			fmt.Println("Sectors larger than 99, or smaller than 2, hexes in either direction are not supported")
+		if mapSize < hexmap.MinPixelSize {
+			fmt.Printf("Map size must be at least %d pixels\n", hexmap.MinPixelSize)
 			return
 		}
+		export.MapPixelSize = mapSize
+		export.HugoTheme = hugoTheme
 
-		var (
-			secData = sector.NewSector(secHeight, secWidth, excludeTags, fullTags, poiChance, otherWorldChance, dVal)
-			secName = genSectorName()
-		)
+		if count < 1 {
+			fmt.Println("Count must be at least 1")
+			return
+		}
+
+		if err := os.MkdirAll(outputDir, dirPerm); err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		newSector := func() (*sector.Stars, error) {
+			s, err := sector.NewSector(rng, secHeight, secWidth, excludeTags, fullTags, poiChance, otherWorldChance, dVal)
+			if err != nil {
+				return nil, err
+			}
+
+			if lanes {
+				s.GenerateLanes(rng)
+			}
+
+			if factions {
+				s.GenerateFactions(rng)
+			}
+
+			return s, nil
+		}
 
-		fmt.Println(secName)
-		fmt.Println(export.Hexmap(secData, true, false))
-
-		ans := "r"
-		for {
-			fmt.Printf("Write Sector? [y]es, [n]o, [r]eroll: [%s] ", ans)
-			fmt.Scanf("%s", &ans)
-			switch ans {
-			case "y":
-				if err := os.Mkdir(secName, dirPerm); err != nil {
-					log.Fatal(err)
+		for i := 0; i < count; i++ {
+			if i > 0 {
+				rng.Reseed() // Give each candidate its own seed so that it can be reproduced with --seed
+			}
+
+			secData, err := newSector()
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			secName := sectorName(outputDir, nameOverride, i, count)
+
+			fmt.Printf("%s (seed %d)\n", secName, secData.Seed)
+			if batch {
+				if err := writeSector(outputDir, secName, secData, exportTypes); err != nil {
+					fmt.Println(err)
+					return
 				}
+				keep(secName, sectorContent{Name: secName, Stars: secData})
+				continue
+			}
+
+			fmt.Println(export.Hexmap(secData, true, false))
 
-				if err := os.Chdir(secName); err != nil {
-					log.Fatal(err)
+			ans := "r"
+		prompt:
+			for {
+				fmt.Printf("Write Sector? [y]es, [n]o, [r]eroll: [%s] ", ans)
+				if _, err := fmt.Scanf("%s", &ans); err == io.EOF { // No more input, i.e stdin is not a terminal
+					fmt.Println()
+					break prompt
 				}
 
-				for _, t := range strings.Split(exportTypes, ",") {
-					if exporter, err := export.New(t, secName, secData); exporter != nil {
-						if err != nil {
-							log.Fatal(err)
-						}
+				switch ans {
+				case "y":
+					if err := writeSector(outputDir, secName, secData, exportTypes); err != nil {
+						fmt.Println(err)
+						return
+					}
+					keep(secName, sectorContent{Name: secName, Stars: secData})
+					break prompt
+
+				case "n":
+					break prompt
 
-						if err = exporter.Write(); err != nil {
-							log.Fatal(err)
-						}
+				case "r":
+					rng.Reseed() // Give each reroll its own seed so that it can be reproduced with --seed
+					if secData, err = newSector(); err != nil {
+						fmt.Println(err)
+						return
 					}
+					secName = sectorName(outputDir, nameOverride, i, count)
+					fmt.Printf("%s (seed %d)\n", secName, secData.Seed)
+					fmt.Println(export.Hexmap(secData, true, false))
 				}
+			}
+		}
+	},
+}
 
-				return
+// writeSector creates the directory for a sector in dir and runs each of the requested exporters in it
+func writeSector(dir, secName string, secData *sector.Stars, exportTypes string) error {
+	wdir, _ := os.Getwd()
+	defer os.Chdir(wdir)
 
-			case "n":
-				return
+	secDir := filepath.Join(dir, secName)
+	if err := os.Mkdir(secDir, dirPerm); err != nil {
+		return err
+	}
+
+	if err := os.Chdir(secDir); err != nil {
+		return err
+	}
 
-			case "r":
-				secData = sector.NewSector(secHeight, secWidth, excludeTags, fullTags, poiChance, otherWorldChance, dVal)
-				secName = genSectorName()
-				fmt.Println(secName)
-				fmt.Println(export.Hexmap(secData, true, false))
+	for _, t := range strings.Split(exportTypes, ",") {
+		if exporter, err := export.New(t, secName, secData); exporter != nil {
+			if err != nil {
+				return err
+			}
+
+			if err = exporter.Write(); err != nil {
+				return err
 			}
 		}
-	},
+	}
+
+	return nil
+}
+
+// sectorContent is a named sector that can be formatted like any other content. It is written to
+// JSON as the sector alone so that it can be exported like any other sector json file.
+type sectorContent struct {
+	Name  string
+	Stars *sector.Stars
 }
 
-func genSectorName() string {
-	secName := fmt.Sprintf("%s Sector", name.System.Roll())
-	_, err := os.Stat(secName) // Ensure that there isn't already a sector of this name in the working directory
-	for os.IsExist(err) {
-		secName = fmt.Sprintf("%s Sector", name.System.Roll())
-		_, err = os.Stat(secName)
+// Format returns the sector's map, lanes, factions and systems formatted as type t
+func (s sectorContent) Format(t format.OutputType) string {
+	buf := new(bytes.Buffer)
+	fmt.Fprint(buf, format.Header(t, 1, s.Name))
+	if t == format.MARKDOWN {
+		fmt.Fprintf(buf, "```\n%s\n```\n\n", export.Hexmap(s.Stars, false, false))
+	} else {
+		fmt.Fprintf(buf, "%s\n\n", export.Hexmap(s.Stars, false, false))
+	}
+
+	if len(s.Stars.Lanes) > 0 {
+		fmt.Fprint(buf, format.Header(t, 2, "Lanes")+s.Stars.Lanes.Format(t)+"\n")
+	}
+
+	if len(s.Stars.Factions) > 0 {
+		fmt.Fprint(buf, format.Header(t, 2, "Factions")+s.Stars.FormatFactions(t)+"\n")
+	}
+
+	for _, star := range s.Stars.Systems {
+		fmt.Fprint(buf, format.Header(t, 1, star.Name)+star.Format(t)+"\n")
+	}
+
+	return buf.String()
+}
+
+// MarshalJSON satisfies json.Marshaler
+func (s sectorContent) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Stars)
+}
+
+// sectorName returns the name for the i'th of count sectors. If no name has been set by the user a
+// random name is generated, otherwise multiple sectors are numbered to keep their directories apart.
+func sectorName(dir, nameOverride string, i, count int) string {
+	switch {
+	case nameOverride == "":
+		return genSectorName(dir)
+	case count > 1:
+		return fmt.Sprintf("%s %d", nameOverride, i+1)
+	default:
+		return nameOverride
+	}
+}
+
+func genSectorName(dir string) string {
+	secName := fmt.Sprintf("%s Sector", rng.Roll(name.System))
+	_, err := os.Stat(filepath.Join(dir, secName)) // Ensure that there isn't already a sector of this name in the output directory
+	for err == nil {
+		secName = fmt.Sprintf("%s Sector", rng.Roll(name.System))
+		_, err = os.Stat(filepath.Join(dir, secName))
 	}
 
 	return secName
@@ -141,6 +268,23 @@ func init() {
 	sectorCmd.Flags().IntP(flOW, "o", 15, "Set % chance for a secondary world to be generated for any given star in the sector")
 	sectorCmd.Flags().IntP(flSecHeight, "e", 10, "Set height of sector in hexes")
 	sectorCmd.Flags().IntP(flSecWidth, "w", 8, "Set width of sector in hexes")
-	sectorCmd.Flags().String(flExport, "txt,json", "Set export formats. Format types must be comma separated without spaces. Supported formats are txt, json and hugo")
+	sectorCmd.Flags().String(flExport, "txt,json", "Set export formats. Format types must be comma separated without spaces. Supported formats are txt, json, html, hugo, obsidian, swn, sec and foundry")
 	sectorCmd.Flags().StringP(flDensity, "d", "average", "Set star density in sector. Options are sparse, average or dense")
+	sectorCmd.Flags().BoolP(flYes, "y", false, "Write sectors without prompting, for use in scripts (alias --batch)")
+	sectorCmd.Flags().String(flOutputDir, ".", "Set the directory that sector directories are written to")
+	sectorCmd.Flags().StringP(flName, "n", "", "Set the sector name instead of generating one. Multiple sectors are numbered")
+	sectorCmd.Flags().IntP(flCount, "c", 1, "Set the number of candidate sectors to generate")
+	sectorCmd.Flags().Int(flMapSize, export.MapPixelSize, "Set the radius in pixels of hexes in PNG maps")
+	sectorCmd.Flags().String(flHugoTheme, "", "Set the theme used by hugo exports. The theme must be installed in the site's themes directory, without one the site uses its own minimal layouts")
+	sectorCmd.Flags().Bool(flLanes, false, "Generate established trade and travel lanes between spacefaring systems")
+	sectorCmd.Flags().Bool(flFactions, false, "Generate 3-6 factions with homeworlds among the sector's more populous and advanced systems")
+
+	// Allow --batch as an alternative to --yes
+	sectorCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == flBatch {
+			name = flYes
+		}
+
+		return pflag.NormalizedName(name)
+	})
 }
@@ -0,0 +1,293 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nboughton/go-utils/json/file"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/export"
+	"github.com/spf13/cobra"
+)
+
+// sectorToolsCmd represents the sector command, for working with existing sectors. Sectors are
+// generated by sectorCmd ("new sector").
+var sectorToolsCmd = &cobra.Command{
+	Use:   "sector",
+	Short: "Work with existing sectors",
+	Long:  ``,
+}
+
+// sectorEditCmd represents the sector edit command
+var sectorEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Add, remove, move, rename and reroll the systems of an exported sector",
+	Long: `Edit the systems of a sector saved as json. Changes are written back to the json file and,
+if --export is set, the sector is exported again alongside it. Exporting replaces the output of any
+previous export of the same type. Hexes are given as row and column as shown on the sector maps,
+i.e --row 3 --col 4 is hex 03,04.`,
+}
+
+var sectorEditAddCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Generate a new system at an empty hex",
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			row, _              = cmd.Flags().GetInt(flRow)
+			col, _              = cmd.Flags().GetInt(flCol)
+			n, _                = cmd.Flags().GetString(flName)
+			excludeTags, _      = cmd.Flags().GetStringArray(flExclude)
+			fullTags, _         = cmd.Flags().GetBool(flLongTags)
+			poiChance, _        = cmd.Flags().GetInt(flPoi)
+			otherWorldChance, _ = cmd.Flags().GetInt(flOW)
+		)
+
+		editSector(cmd, func(s *sector.Stars) (*sector.Star, error) {
+			star, err := s.AddStar(rng, row, col, n, excludeTags, fullTags, poiChance, otherWorldChance)
+			if err != nil {
+				return nil, err
+			}
+
+			fmt.Printf("Added %s at %s\n", star.Name, star.Hex())
+			return star, nil
+		})
+	},
+}
+
+var sectorEditRemoveCmd = &cobra.Command{
+	Use:   "remove",
+	Short: "Remove a system, along with its lanes",
+	Run: func(cmd *cobra.Command, args []string) {
+		n, _ := cmd.Flags().GetString(flStar)
+
+		editSector(cmd, func(s *sector.Stars) (*sector.Star, error) {
+			star, err := s.Find(n)
+			if err != nil {
+				return nil, err
+			}
+
+			homeless, err := s.RemoveStar(star.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			fmt.Printf("Removed %s\n", star.Name)
+			for _, f := range homeless {
+				fmt.Printf("%s no longer has a homeworld\n", f)
+			}
+			return nil, nil
+		})
+	},
+}
+
+var sectorEditMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Move a system to an empty hex",
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			n, _   = cmd.Flags().GetString(flStar)
+			row, _ = cmd.Flags().GetInt(flRow)
+			col, _ = cmd.Flags().GetInt(flCol)
+		)
+
+		editSector(cmd, func(s *sector.Stars) (*sector.Star, error) {
+			if err := s.MoveStar(n, row, col); err != nil {
+				return nil, err
+			}
+
+			star, _ := s.Find(n)
+			fmt.Printf("Moved %s to %s\n", star.Name, star.Hex())
+			return star, nil
+		})
+	},
+}
+
+var sectorEditRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Rename a system, updating the lanes and factions that refer to it",
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			n, _    = cmd.Flags().GetString(flStar)
+			name, _ = cmd.Flags().GetString(flName)
+		)
+
+		editSector(cmd, func(s *sector.Stars) (*sector.Star, error) {
+			star, err := s.Find(n)
+			if err != nil {
+				return nil, err
+			}
+
+			old := star.Name
+			if err := s.RenameStar(old, name); err != nil {
+				return nil, err
+			}
+
+			fmt.Printf("Renamed %s to %s\n", old, star.Name)
+			return star, nil
+		})
+	},
+}
+
+var sectorEditRerollCmd = &cobra.Command{
+	Use:   "reroll",
+	Short: "Reroll a single world or point of interest of a system",
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			n, _           = cmd.Flags().GetString(flStar)
+			world, _       = cmd.Flags().GetInt(flWorld)
+			poi, _         = cmd.Flags().GetInt(flPOI)
+			excludeTags, _ = cmd.Flags().GetStringArray(flExclude)
+			fullTags, _    = cmd.Flags().GetBool(flLongTags)
+		)
+
+		if cmd.Flags().Changed(flWorld) == cmd.Flags().Changed(flPOI) {
+			fmt.Println("Set either --world or --poi")
+			return
+		}
+
+		editSector(cmd, func(s *sector.Stars) (*sector.Star, error) {
+			var err error
+			if cmd.Flags().Changed(flWorld) {
+				_, err = s.RerollWorld(rng, n, world, excludeTags, fullTags)
+			} else {
+				_, err = s.RerollPOI(rng, n, poi)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			star, _ := s.Find(n)
+			fmt.Printf("Rerolled %s\n", star.Name)
+			return star, nil
+		})
+	},
+}
+
+// editSector loads the sector json file set for cmd, applies edit to it then writes it back and
+// re-exports it. The Star returned by edit, if any, is shown.
+func editSector(cmd *cobra.Command, edit func(*sector.Stars) (*sector.Star, error)) {
+	var (
+		jsonFile, _    = cmd.Flags().GetString(flFile)
+		exportTypes, _ = cmd.Flags().GetString(flExport)
+		secData        = new(sector.Stars)
+	)
+
+	if jsonFile == "" {
+		fmt.Println("Set the sector json file with -i")
+		return
+	}
+
+	if err := file.Scan(jsonFile, &secData); err != nil {
+		fmt.Println("Error reading file.", err)
+		return
+	}
+
+	star, err := edit(secData)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if star != nil {
+		fmt.Fprint(tw, star.Format(format.TEXT))
+		fmt.Fprintln(tw)
+		tw.Flush()
+	}
+
+	if err := file.Write(jsonFile, secData); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	if exportTypes == "" {
+		return
+	}
+
+	if err := reexportSector(jsonFile, secData, exportTypes); err != nil {
+		fmt.Println("Error exporting sector:", err)
+	}
+}
+
+// reexportSector exports secData in the directory of its json file, replacing previous exports
+func reexportSector(jsonFile string, secData *sector.Stars, exportTypes string) error {
+	wdir, _ := os.Getwd()
+	defer os.Chdir(wdir)
+
+	if err := os.Chdir(filepath.Dir(jsonFile)); err != nil {
+		return err
+	}
+
+	secName := strings.TrimSuffix(filepath.Base(jsonFile), ".json")
+	for _, t := range strings.Split(exportTypes, ",") {
+		exporter, err := export.New(t, secName, secData)
+		if err != nil {
+			return err
+		}
+
+		if err := export.Clean(t); err != nil {
+			return err
+		}
+
+		if err := exporter.Write(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(sectorToolsCmd)
+	sectorToolsCmd.AddCommand(sectorEditCmd)
+	sectorEditCmd.AddCommand(sectorEditAddCmd, sectorEditRemoveCmd, sectorEditMoveCmd, sectorEditRenameCmd, sectorEditRerollCmd)
+
+	sectorEditCmd.PersistentFlags().StringP(flFile, "i", "", "Path to the sector json file")
+	sectorEditCmd.PersistentFlags().String(flExport, "", "Export the sector again after editing. Format types must be comma separated without spaces, i.e txt,hugo")
+
+	sectorEditAddCmd.Flags().Int(flRow, -1, "Row of the hex to add the system to")
+	sectorEditAddCmd.Flags().Int(flCol, -1, "Column of the hex to add the system to")
+	sectorEditAddCmd.Flags().StringP(flName, "n", "", "Set the name of the system. Generated if not set")
+	sectorEditAddCmd.Flags().StringArrayP(flExclude, "x", []string{}, "Exclude tags (-x zombies -x \"regional hegemon\" etc)")
+	sectorEditAddCmd.Flags().BoolP(flLongTags, "l", false, "Toggle full world tag info in output")
+	sectorEditAddCmd.Flags().IntP(flPoi, "p", 40, "Set % chance of a POI being generated for the system")
+	sectorEditAddCmd.Flags().IntP(flOW, "o", 15, "Set % chance for a secondary world to be generated for the system")
+
+	sectorEditRemoveCmd.Flags().String(flStar, "", "Name of the system to remove")
+
+	sectorEditMoveCmd.Flags().String(flStar, "", "Name of the system to move")
+	sectorEditMoveCmd.Flags().Int(flRow, -1, "Row of the hex to move the system to")
+	sectorEditMoveCmd.Flags().Int(flCol, -1, "Column of the hex to move the system to")
+
+	sectorEditRenameCmd.Flags().String(flStar, "", "Name of the system to rename")
+	sectorEditRenameCmd.Flags().StringP(flName, "n", "", "New name of the system")
+
+	sectorEditRerollCmd.Flags().String(flStar, "", "Name of the system")
+	sectorEditRerollCmd.Flags().Int(flWorld, 0, "Reroll the world with this number, counting from 0 (the primary world)")
+	sectorEditRerollCmd.Flags().Int(flPOI, 0, "Reroll the point of interest with this number, counting from 0")
+	sectorEditRerollCmd.Flags().StringArrayP(flExclude, "x", []string{}, "Exclude tags from rerolled worlds (-x zombies -x \"regional hegemon\" etc)")
+	sectorEditRerollCmd.Flags().BoolP(flLongTags, "l", false, "Toggle full world tag info in output")
+}
@@ -0,0 +1,145 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nboughton/go-utils/json/file"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/spf13/cobra"
+)
+
+// sectorQueryCmd represents the sector query command
+var sectorQueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Find the worlds of an exported sector that match a set of filters",
+	Long: `Find worlds in a sector saved as json. Worlds must match every filter that is set. Tags and
+cultures match by name, atmosphere, biosphere, population and poi match any value containing the
+text given (ignoring case), i.e --atmosphere breathable finds worlds with a "Breathable mix".`,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			jsonFile, _ = cmd.Flags().GetString(flFile)
+			fmc, _      = cmd.Flags().GetString(flFormat)
+			tags, _     = cmd.Flags().GetStringArray(flTag)
+			tl, _       = cmd.Flags().GetString(flTL)
+			minTL, _    = cmd.Flags().GetString(flMinTL)
+			atmo, _     = cmd.Flags().GetString(flAtmosphere)
+			bio, _      = cmd.Flags().GetString(flBiosphere)
+			pop, _      = cmd.Flags().GetString(flPopulation)
+			ctr, _      = cmd.Flags().GetString(flCulture)
+			poi, _      = cmd.Flags().GetString(flPOI)
+		)
+
+		secData := new(sector.Stars)
+		if err := file.Scan(jsonFile, &secData); err != nil {
+			fmt.Println("Error reading file.", err)
+			return
+		}
+
+		res, err := secData.Query(sector.Query{
+			Tags:       tags,
+			TechLevel:  tl,
+			MinTL:      minTL,
+			Atmosphere: atmo,
+			Biosphere:  bio,
+			Population: pop,
+			Culture:    ctr,
+			POI:        poi,
+		})
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		var worlds int
+		for _, s := range secData.Systems {
+			worlds += len(s.Worlds)
+		}
+
+		var rows [][]string
+		for _, r := range res {
+			var pois []string
+			for _, p := range r.Star.POIs {
+				pois = append(pois, p.Point)
+			}
+
+			rows = append(rows, []string{
+				r.Star.Hex().String(),
+				r.Star.Name,
+				r.World.Name,
+				r.World.TechLevel.Code,
+				r.World.Atmosphere.Desc,
+				r.World.Biosphere.Desc,
+				r.World.Population.Desc,
+				r.World.Culture.String(),
+				r.World.Tags[0].Name + ", " + r.World.Tags[1].Name,
+				strings.Join(pois, ", "),
+			})
+		}
+
+		var fIDs []format.OutputType
+		for _, f := range strings.Split(fmc, ",") {
+			fID, err := format.Find(f)
+			if err != nil {
+				fmt.Println(err)
+				return
+			}
+
+			fIDs = append(fIDs, fID)
+		}
+
+		if len(rows) == 0 {
+			for _, fID := range fIDs {
+				if fID == format.JSON {
+					fmt.Println("[]")
+				} else {
+					fmt.Println("No worlds found")
+				}
+			}
+
+			return
+		}
+
+		for _, fID := range fIDs {
+			fmt.Fprintf(tw, format.Header(fID, 2, fmt.Sprintf("%d of %d worlds found", len(rows), worlds)))
+			fmt.Fprintf(tw, format.Table(fID, []string{"Hex", "Star", "World", "TL", "Atmosphere", "Biosphere", "Population", "Culture", "Tags", "POIs"}, rows))
+			fmt.Fprintln(tw)
+			tw.Flush()
+		}
+	},
+}
+
+func init() {
+	sectorToolsCmd.AddCommand(sectorQueryCmd)
+	sectorQueryCmd.Flags().StringP(flFile, "i", "", "Path to sector json file")
+	sectorQueryCmd.Flags().StringP(flFormat, "f", "txt", "Set output format. (--format txt,md,json)")
+	sectorQueryCmd.Flags().StringArrayP(flTag, "t", []string{}, "Find worlds with a tag (-t zombies -t \"regional hegemon\" finds worlds with both)")
+	sectorQueryCmd.Flags().String(flTL, "", "Find worlds of a tech level (TL0, TL1, TL2, TL3, TL4, TL4+ or TL5)")
+	sectorQueryCmd.Flags().String(flMinTL, "", "Find worlds of a tech level or higher, i.e TL4 finds TL4, TL4+ and TL5 worlds")
+	sectorQueryCmd.Flags().String(flAtmosphere, "", "Find worlds with an atmosphere")
+	sectorQueryCmd.Flags().String(flBiosphere, "", "Find worlds with a biosphere")
+	sectorQueryCmd.Flags().String(flPopulation, "", "Find worlds with a population")
+	sectorQueryCmd.Flags().StringP(flCulture, "c", "", "Find worlds of a culture")
+	sectorQueryCmd.Flags().String(flPOI, "", "Find worlds in systems with a type of point of interest, i.e \"research base\"")
+}
@@ -0,0 +1,466 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/culture"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/gender"
+	"github.com/nboughton/swnt/content/name"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/dice"
+	"github.com/spf13/cobra"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the generators as a JSON API and web page",
+	Long: `Serve every generator as a JSON endpoint at /api/<generator>, along with a web page at / for
+rolling from a browser. Query parameters use the same names as the flags of the matching "new"
+command, i.e /api/npc?culture=greek&gender=female or /api/sector?sector-height=6&lanes=true.
+Flags that can be set more than once, such as exclude, are repeated.
+
+Every response has the generated content as data and its markdown as md, along with the seed it
+was generated from. Setting the seed parameter reproduces a result. /api/ lists the generators.
+
+The server listens on localhost only unless --addr is set to another address.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		addr, _ := cmd.Flags().GetString(flAddr)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				http.NotFound(w, r)
+				return
+			}
+
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprint(w, servePage)
+		})
+		mux.HandleFunc("/api/", serveAPI)
+
+		// Responses are never shown in a terminal. This is set once, before any request is handled,
+		// as the colour package reads it from every goroutine.
+		color.NoColor = true
+
+		fmt.Printf("Serving on http://%s/\n", addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			fmt.Println(err)
+		}
+	},
+}
+
+// generator creates content from the query parameters of a request and returns it with its markdown
+type generator func(rng *dice.Rand, q url.Values) (interface{}, string, error)
+
+// generators available from the API by name
+var generators = map[string]generator{
+	"adventure": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		tag := q.Get(flTag)
+		if tag == "" {
+			tag = content.Tags.Random(rng)
+		}
+
+		a := content.NewAdventure(rng, tag) // Adventures are plain text in every format
+		return a, a.String() + "\n", nil
+	},
+	"alien": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		return markdown(content.NewAlien(rng))
+	},
+	"beast": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		return markdown(content.NewBeast(rng))
+	},
+	"conflict": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		return markdown(content.NewConflict(rng))
+	},
+	"corporation": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		return markdown(content.NewCorporation(rng))
+	},
+	"culture": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		c := culture.Random(rng)
+		return c, c.String() + "\n", nil
+	},
+	"encounter": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		wild, err := queryBool(q, flWilderness)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return markdown(content.NewEncounter(rng, wild))
+	},
+	"faction": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		s, err := content.FindFactionSize(rng, q.Get(flSize))
+		if err != nil {
+			return nil, "", err
+		}
+
+		return markdown(content.NewFaction(rng, s, q.Get(flHomeworld)))
+	},
+	"heresy": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		return markdown(content.NewHeresy(rng))
+	},
+	"npc": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		c, err := culture.Find(rng, q.Get(flCulture))
+		if err != nil {
+			return nil, "", err
+		}
+
+		g, err := gender.Find(rng, q.Get(flGender))
+		if err != nil {
+			return nil, "", err
+		}
+
+		patron, err := queryBool(q, flPatron)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return markdown(content.NewNPC(rng, c, g, patron))
+	},
+	"place": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		wild, err := queryBool(q, flWilderness)
+		if err != nil {
+			return nil, "", err
+		}
+
+		return markdown(content.NewPlace(rng, wild))
+	},
+	"poi": func(rng *dice.Rand, q url.Values) (interface{}, string, error) { return markdown(content.NewPOI(rng)) },
+	"religion": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		return markdown(content.NewReligion(rng))
+	},
+	"sector": serveSector,
+	"ship": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		h, err := content.FindHullClass(rng, q.Get(flClass))
+		if err != nil {
+			return nil, "", err
+		}
+
+		c, err := culture.Find(rng, q.Get(flCulture))
+		if err != nil {
+			return nil, "", err
+		}
+
+		return markdown(content.NewStarship(rng, h, c))
+	},
+	"world": func(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+		c, err := culture.Find(rng, q.Get(flCulture))
+		if err != nil {
+			return nil, "", err
+		}
+
+		long, err := queryBool(q, flLongTags)
+		if err != nil {
+			return nil, "", err
+		}
+
+		w, err := content.NewWorld(rng, false, c, long, q[flExclude])
+		if err != nil {
+			return nil, "", err
+		}
+
+		return markdown(w)
+	},
+}
+
+// serveSector generates a sector. Unlike "new sector" nothing is written to disk, the sector is
+// returned with the ASCII map and every system in its markdown.
+func serveSector(rng *dice.Rand, q url.Values) (interface{}, string, error) {
+	var (
+		rows, err1     = queryInt(q, flSecHeight, 10)
+		cols, err2     = queryInt(q, flSecWidth, 8)
+		poi, err3      = queryInt(q, flPoi, 40)
+		others, err4   = queryInt(q, flOW, 15)
+		long, err5     = queryBool(q, flLongTags)
+		lanes, err6    = queryBool(q, flLanes)
+		factions, err7 = queryBool(q, flFactions)
+		name           = q.Get(flName)
+	)
+
+	for _, err := range []error{err1, err2, err3, err4, err5, err6, err7} {
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	d := q.Get(flDensity)
+	if d == "" {
+		d = "average" // As for new sector
+	}
+
+	density, err := sector.ParseDensity(d)
+	if err != nil {
+		return nil, "", err
+	}
+
+	s, err := sector.NewSector(rng, rows, cols, q[flExclude], long, poi, others, density)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if lanes {
+		s.GenerateLanes(rng)
+	}
+
+	if factions {
+		s.GenerateFactions(rng)
+	}
+
+	if name == "" {
+		name = sectorTitle(rng)
+	}
+
+	return s, sectorContent{Name: name, Stars: s}.Format(format.MARKDOWN), nil
+}
+
+// sectorTitle returns a random sector name
+func sectorTitle(rng *dice.Rand) string {
+	return fmt.Sprintf("%s Sector", rng.Roll(name.System))
+}
+
+// markdown returns c with its markdown
+func markdown(c formatter) (interface{}, string, error) {
+	return c, c.Format(format.MARKDOWN), nil
+}
+
+// queryBool returns the boolean value of parameter n, or false if it is not set. A parameter
+// without a value (i.e ?wilderness) is true.
+func queryBool(q url.Values, n string) (bool, error) {
+	v, ok := q[n]
+	if !ok {
+		return false, nil
+	}
+
+	if v[0] == "" {
+		return true, nil
+	}
+
+	b, err := strconv.ParseBool(v[0])
+	if err != nil {
+		return false, fmt.Errorf("%s must be true or false", n)
+	}
+
+	return b, nil
+}
+
+// queryInt returns the integer value of parameter n, or def if it is not set
+func queryInt(q url.Values, n string, def int) (int, error) {
+	v := q.Get(n)
+	if v == "" {
+		return def, nil
+	}
+
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%s must be a number", n)
+	}
+
+	return i, nil
+}
+
+// serveResult is the body of a successful API response
+type serveResult struct {
+	Generator string      `json:"generator"`
+	Seed      int64       `json:"seed"`
+	Data      interface{} `json:"data"`
+	MD        string      `json:"md"`
+}
+
+// serveAPI handles /api/<generator> requests, /api/ lists the generators
+func serveAPI(w http.ResponseWriter, r *http.Request) {
+	var (
+		n = strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/"), "/")
+		q = r.URL.Query()
+	)
+
+	if n == "" {
+		var names []string
+		for g := range generators {
+			names = append(names, g)
+		}
+		sort.Strings(names)
+
+		serveJSON(w, http.StatusOK, names)
+		return
+	}
+
+	gen, ok := generators[n]
+	if !ok {
+		serveJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("no generator called \"%s\"", n)})
+		return
+	}
+
+	// Every request has its own random source so that its result can be reproduced with the seed
+	// parameter
+	seed := time.Now().UnixNano()
+	if s := q.Get(flSeed); s != "" {
+		var err error
+		if seed, err = strconv.ParseInt(s, 10, 64); err != nil {
+			serveJSON(w, http.StatusBadRequest, map[string]string{"error": "seed must be a number"})
+			return
+		}
+	}
+
+	res := serveResult{Generator: n, Seed: seed}
+
+	var err error
+	if res.Data, res.MD, err = gen(dice.New(seed), q); err != nil {
+		serveJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	serveJSON(w, http.StatusOK, res)
+}
+
+// serveJSON writes v as the JSON body of a response with status code
+func serveJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Println(err)
+	}
+}
+
+// servePage is the web page served at /. It renders the markdown of each result with a minimal
+// renderer that covers what swnt generates: headers, tables, lists, code blocks and paragraphs.
+const servePage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>swnt</title>
+<style>
+body { background: #181818; color: #e5e5e5; font-family: sans-serif; margin: 0 auto; max-width: 60em; padding: 1em; }
+a { color: #11a8cd; }
+h1, h2, h3 { color: #0dbc79; }
+button, input { background: #252525; border: 1px solid #505050; color: #e5e5e5; font-size: 1em; margin: 0.1em; padding: 0.3em 0.6em; }
+button:hover { border-color: #0dbc79; cursor: pointer; }
+#params { box-sizing: border-box; width: 100%; }
+table { border-collapse: collapse; margin: 0.5em 0 1em; width: 100%; }
+th, td { border: 1px solid #505050; padding: 0.25em 0.5em; text-align: left; vertical-align: top; }
+th { background: #252525; }
+pre { overflow-x: auto; }
+.meta { color: #a0a0a0; font-size: 0.9em; }
+.error { color: #cd3131; }
+</style>
+</head>
+<body>
+<h1>swnt</h1>
+<div id="generators"></div>
+<p><input id="params" placeholder="Parameters, i.e culture=greek&amp;gender=female or seed=1234"></p>
+<div id="result"></div>
+<script>
+(function () {
+  var result = document.getElementById("result");
+  var params = document.getElementById("params");
+
+  function esc(s) {
+    return s.replace(/&/g, "&amp;").replace(/</g, "&lt;").replace(/>/g, "&gt;");
+  }
+
+  function cells(line) {
+    return line.replace(/^\s*\|/, "").replace(/\|\s*$/, "").split("|").map(function (c) { return esc(c.trim()); });
+  }
+
+  function render(md) {
+    var out = [], lines = md.split("\n"), i = 0;
+    while (i < lines.length) {
+      var l = lines[i];
+      if (l.indexOf("` + "```" + `") === 0) {
+        var code = [];
+        for (i++; i < lines.length && lines[i].indexOf("` + "```" + `") !== 0; i++) code.push(esc(lines[i]));
+        out.push("<pre>" + code.join("\n") + "</pre>");
+        i++;
+      } else if (/^#{1,6} /.test(l)) {
+        var n = l.indexOf(" ");
+        out.push("<h" + n + ">" + esc(l.slice(n + 1)) + "</h" + n + ">");
+        i++;
+      } else if (/^\s*\|/.test(l)) {
+        var head = cells(l), rows = [];
+        for (i++; i < lines.length && /^\s*\|/.test(lines[i]); i++) {
+          if (!/^[\s|:-]+$/.test(lines[i])) rows.push(cells(lines[i]));
+        }
+        var t = "<table>";
+        if (head.join("") !== "") t += "<tr><th>" + head.join("</th><th>") + "</th></tr>";
+        rows.forEach(function (r) { t += "<tr><td>" + r.join("</td><td>") + "</td></tr>"; });
+        out.push(t + "</table>");
+      } else if (/^- /.test(l)) {
+        var items = [];
+        for (; i < lines.length && /^- /.test(lines[i]); i++) items.push("<li>" + esc(lines[i].slice(2)) + "</li>");
+        out.push("<ul>" + items.join("") + "</ul>");
+      } else if (l.trim() !== "") {
+        out.push("<p>" + esc(l) + "</p>");
+        i++;
+      } else {
+        i++;
+      }
+    }
+    return out.join("\n");
+  }
+
+  function generate(name) {
+    var url = "/api/" + name + (params.value ? "?" + params.value.replace(/^\?/, "") : "");
+    fetch(url).then(function (r) { return r.json(); }).then(function (res) {
+      if (res.error) {
+        result.innerHTML = '<p class="error">' + esc(res.error) + "</p>";
+        return;
+      }
+      var link = url + (url.indexOf("?") === -1 ? "?" : "&") + "seed=" + res.seed;
+      result.innerHTML = '<p class="meta">' + esc(name) + " seed " + res.seed +
+        ' <a href="' + esc(link) + '" target="_blank">json</a></p>' + render(res.md);
+    });
+  }
+
+  fetch("/api/").then(function (r) { return r.json(); }).then(function (names) {
+    var div = document.getElementById("generators");
+    names.forEach(function (n) {
+      var b = document.createElement("button");
+      b.textContent = n;
+      b.addEventListener("click", function () { generate(n); });
+      div.appendChild(b);
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
+
+func init() {
+	RootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().String(flAddr, "localhost:8080", "Set the address to listen on")
+}
@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/dice"
+	"github.com/nboughton/swnt/export"
+)
+
+// TestServeConcurrentSectors requests sectors while coloured maps are drawn on other goroutines, run
+// it with -race to check that maps don't share state
+func TestServeConcurrentSectors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(serveAPI))
+	defer srv.Close()
+
+	stars, err := sector.NewSector(dice.New(1), 10, 8, nil, false, 40, 15, sector.AVERAGE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+
+		go func(seed int) {
+			defer wg.Done()
+
+			res, err := http.Get(fmt.Sprintf("%s/api/sector?seed=%d&lanes=true&factions=true", srv.URL, seed))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer res.Body.Close()
+
+			var r serveResult
+			if err := json.NewDecoder(res.Body).Decode(&r); err != nil {
+				t.Errorf("seed %d: decoding response: %s", seed, err)
+				return
+			}
+
+			if res.StatusCode != http.StatusOK || r.MD == "" {
+				t.Errorf("seed %d: response %d with %d bytes of markdown", seed, res.StatusCode, len(r.MD))
+			}
+
+			if strings.Contains(r.MD, "\x1b[") {
+				t.Errorf("seed %d: markdown contains terminal colour codes", seed)
+			}
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			if m := export.Hexmap(stars, true, false); !strings.Contains(m, "\x1b[") {
+				t.Error("coloured map contains no terminal colour codes")
+			}
+		}()
+	}
+
+	wg.Wait()
+}
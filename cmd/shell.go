@@ -0,0 +1,346 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/nboughton/swnt/content/format"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/term"
+)
+
+const shellHelp = `Enter any swnt command without the leading "swnt", i.e "new npc -g female". Other commands are:
+
+  reroll (r)         Run the last command that generated content again
+  save [file]        Append the last generated content to file (default swnt-session.txt, or the
+                     extension of the format set)
+  format [format]    Show or set the output format of commands that support --format (txt, md,
+                     json or html)
+  help               Show this help, use "help new" or "new -h" for help with swnt commands
+  exit (quit)        Leave the shell
+`
+
+// shellCmd represents the shell command
+var shellCmd = &cobra.Command{
+	Use:   "shell",
+	Short: "Start an interactive session for running swnt commands",
+	Long: `Start an interactive session for running swnt commands without reinvoking swnt each time. The
+shell has line editing and history (use the arrow keys) and remembers the last generated content so
+that it can be rerolled or saved.
+
+` + shellHelp,
+	Run: func(cmd *cobra.Command, args []string) {
+		fmc, _ := cmd.Flags().GetString(flFormat)
+
+		s := &shell{format: fmc}
+		if err := s.run(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	},
+}
+
+// shell holds the state of an interactive session
+type shell struct {
+	format   string             // Format set for commands that support --format
+	lastArgs []string           // Last command to generate content
+	last     []generatedContent // Content generated by lastArgs
+	out      io.Writer
+}
+
+// run reads and runs commands until the input ends or the user exits. When stdin is a terminal it is
+// put into raw mode for line editing, and restored while each command runs so that commands (and
+// their prompts) behave as they do outside the shell.
+func (s *shell) run() error {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		s.out = os.Stdout
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			if !s.exec(scanner.Text(), nil) {
+				return nil
+			}
+		}
+
+		return scanner.Err()
+	}
+
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return err
+	}
+	defer term.Restore(fd, state)
+
+	t := term.NewTerminal(struct {
+		io.Reader
+		io.Writer
+	}{os.Stdin, os.Stdout}, "swnt> ")
+	s.out = t
+
+	fmt.Fprintln(t, `swnt shell, enter "help" for help or "exit" to leave`)
+	for {
+		line, err := t.ReadLine()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if !s.exec(line, func(raw bool) {
+			if raw {
+				term.MakeRaw(fd)
+			} else {
+				term.Restore(fd, state)
+			}
+		}) {
+			return nil
+		}
+	}
+}
+
+// exec runs a single line of input, returning false when the shell should exit. setRaw, if not
+// nil, is used to leave raw mode while swnt commands run.
+func (s *shell) exec(line string, setRaw func(bool)) bool {
+	args, err := splitArgs(line)
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return true
+	}
+
+	if len(args) == 0 {
+		return true
+	}
+
+	switch args[0] {
+	case "exit", "quit":
+		return false
+
+	case "help":
+		if len(args) == 1 {
+			fmt.Fprint(s.out, shellHelp)
+			return true
+		}
+
+	case "format":
+		s.setFormat(args[1:])
+		return true
+
+	case "save":
+		s.save(args[1:])
+		return true
+
+	case "reroll", "r":
+		if s.lastArgs == nil {
+			fmt.Fprintln(s.out, "Nothing to reroll yet")
+			return true
+		}
+		args = s.lastArgs
+
+	case "shell":
+		fmt.Fprintln(s.out, "Already in the shell")
+		return true
+	}
+
+	if setRaw != nil {
+		setRaw(false)
+		defer setRaw(true)
+	}
+
+	defer func() { // A failing command shouldn't end the session
+		if r := recover(); r != nil {
+			fmt.Fprintln(s.out, "Error:", r)
+		}
+	}()
+
+	generated = nil
+	resetFlags(RootCmd)
+	if c, _, err := RootCmd.Find(args); err == nil && s.format != "" {
+		if f := c.Flag(flFormat); f != nil {
+			f.Value.Set(s.format)
+			f.Changed = true
+		}
+	}
+
+	RootCmd.SetArgs(args)
+	err = RootCmd.Execute()
+	tw.Flush()
+
+	if err == nil && len(generated) > 0 {
+		s.lastArgs, s.last = args, generated
+	}
+
+	return true
+}
+
+// setFormat shows or sets the output format of commands run in the shell
+func (s *shell) setFormat(args []string) {
+	if len(args) == 0 {
+		fmt.Fprintf(s.out, "Format is %s\n", s.format)
+		return
+	}
+
+	for _, f := range strings.Split(args[0], ",") {
+		if _, err := format.Find(f); err != nil {
+			fmt.Fprintln(s.out, err)
+			return
+		}
+	}
+
+	s.format = args[0]
+}
+
+// save appends the last generated content to a file
+func (s *shell) save(args []string) {
+	if s.lastArgs == nil {
+		fmt.Fprintln(s.out, "Nothing to save yet")
+		return
+	}
+
+	fmc := s.format
+	if fmc == "" {
+		fmc = format.TEXT.String()
+	}
+	fmts := strings.Split(fmc, ",")
+
+	path := "swnt-session." + fmts[0]
+	if len(args) > 0 {
+		path = args[0]
+	}
+
+	var (
+		buf = new(bytes.Buffer)
+		w   = tabwriter.NewWriter(buf, 1, 2, 1, ' ', 0) // Aligned as tw aligns it
+	)
+	for _, f := range fmts {
+		fID, err := format.Find(f)
+		if err != nil {
+			fmt.Fprintln(s.out, err)
+			return
+		}
+
+		for _, g := range s.last {
+			text, err := render(fID, g.content)
+			if err != nil {
+				fmt.Fprintln(s.out, err)
+				return
+			}
+
+			fmt.Fprintln(w, text)
+		}
+	}
+	w.Flush()
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+	defer f.Close()
+
+	if _, err := buf.WriteTo(f); err != nil {
+		fmt.Fprintln(s.out, err)
+		return
+	}
+
+	fmt.Fprintf(s.out, "Saved to %s\n", path)
+}
+
+// resetFlags returns the flags of c and its sub-commands to their defaults, as cobra keeps the
+// values set by one run of a command for the next.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+
+		if v, ok := f.Value.(pflag.SliceValue); ok {
+			var def []string
+			if d := strings.Trim(f.DefValue, "[]"); d != "" {
+				def = strings.Split(d, ",")
+			}
+			v.Replace(def)
+		} else {
+			f.Value.Set(f.DefValue)
+		}
+
+		f.Changed = false
+	}
+
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
+// splitArgs splits line into arguments on white space. Single or double quotes group words into a
+// single argument, i.e new sector --name "Frontier Reach".
+func splitArgs(line string) ([]string, error) {
+	var (
+		args  []string
+		arg   = new(bytes.Buffer)
+		quote rune
+		inArg bool
+	)
+
+	for _, r := range line {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			arg.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case r == ' ' || r == '\t':
+			if inArg {
+				args = append(args, arg.String())
+				arg.Reset()
+				inArg = false
+			}
+		default:
+			arg.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unclosed quote in \"%s\"", line)
+	}
+
+	if inArg {
+		args = append(args, arg.String())
+	}
+
+	return args, nil
+}
+
+func init() {
+	RootCmd.AddCommand(shellCmd)
+	shellCmd.Flags().StringP(flFormat, "f", "", "Set the output format of commands that support --format (txt, md, json or html)")
+}
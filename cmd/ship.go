@@ -0,0 +1,64 @@
+// Copyright © 2018 Nick Boughton <nicholasboughton@gmail.com>
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/culture"
+	"github.com/spf13/cobra"
+)
+
+// shipCmd represents the ship command
+var shipCmd = &cobra.Command{
+	Use:   "ship",
+	Short: "Generate a Starship with weapons, defences, fittings and crew",
+	Long:  ``,
+	Run: func(cmd *cobra.Command, args []string) {
+		var (
+			class, _ = cmd.Flags().GetString(flClass)
+			clt, _   = cmd.Flags().GetString(flCulture)
+			fmc, _   = cmd.Flags().GetString(flFormat)
+		)
+
+		c, err := content.FindHullClass(rng, class)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		cID, err := culture.Find(rng, clt)
+		if err != nil {
+			fmt.Println(err)
+			return
+		}
+
+		s := content.NewStarship(rng, c, cID)
+		output(fmc, s)
+	},
+}
+
+func init() {
+	newCmd.AddCommand(shipCmd)
+	shipCmd.Flags().String(flClass, "", fmt.Sprintf("Select hull class, choices are: %v. Random if not set", content.HullClasses))
+	shipCmd.Flags().StringP(flCulture, "c", "any", fmt.Sprintf("Select Culture of the crew, choices are: %v", culture.Cultures))
+}
@@ -22,11 +22,9 @@ package cmd
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/nboughton/swnt/content"
 	"github.com/nboughton/swnt/content/culture"
-	"github.com/nboughton/swnt/content/format"
 	"github.com/spf13/cobra"
 )
 
@@ -46,27 +44,22 @@ var worldCmd = &cobra.Command{
 		)
 
 		if ctr == "" {
-			cID = culture.Random()
+			cID = culture.Random(rng)
 		} else {
-			cID, err = culture.Find(ctr)
+			cID, err = culture.Find(rng, ctr)
 			if err != nil {
 				fmt.Printf("No Culture found for \"%s\", options are %v\n", ctr, culture.Cultures)
 				return
 			}
 		}
 
-		w := content.NewWorld(false, cID, flt, exc)
-		for _, f := range strings.Split(fmc, ",") {
-			fID, err := format.Find(f)
-			if err != nil {
-				fmt.Println(err)
-				return
-			}
-
-			fmt.Fprintf(tw, w.Format(fID))
-			fmt.Fprintln(tw)
-			tw.Flush()
+		w, err := content.NewWorld(rng, false, cID, flt, exc)
+		if err != nil {
+			fmt.Println(err)
+			return
 		}
+
+		output(fmc, w)
 	},
 }
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Adventure represents the elements of an Adventure outline
@@ -25,19 +26,19 @@ type tagFields struct {
 }
 
 // NewAdventure throws together a random adventure seed using the table available from Stars Without Number
-func NewAdventure(worldTag string) Adventure {
+func NewAdventure(rng *dice.Rand, worldTag string) Adventure {
 	a := Adventure{
-		Seed: adventureSeedTable.Roll(),
+		Seed: rng.Roll(adventureSeedTable),
 		Tag:  tagFields{Name: worldTag},
 	}
 
 	t, err := Tags.Find(worldTag)
 	if err == nil {
-		a.Tag.Enemy = t.Enemies.Roll()
-		a.Tag.Friend = t.Friends.Roll()
-		a.Tag.Thing = t.Things.Roll()
-		a.Tag.Place = t.Places.Roll()
-		a.Tag.Complication = t.Complications.Roll()
+		a.Tag.Enemy = rng.Roll(t.Enemies)
+		a.Tag.Friend = rng.Roll(t.Friends)
+		a.Tag.Thing = rng.Roll(t.Things)
+		a.Tag.Place = rng.Roll(t.Places)
+		a.Tag.Complication = rng.Roll(t.Complications)
 	}
 
 	return a
@@ -3,7 +3,7 @@ package content
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/nboughton/go-roll"
@@ -15,6 +15,10 @@ import (
 func init() {
 	table.Registry.Add(alienTable.body)
 	table.Registry.Add(alienTable.socialStructure)
+
+	dice.AddAction("alien.Body", "Hybrid of two or more types", actionHybridBody)
+	dice.AddAction("alien.SocialStructure", "Multipolar Competitive", actionSocialStructure)
+	dice.AddAction("alien.SocialStructure", "Multipolar Cooperative", actionSocialStructure)
 }
 
 // Alien with a Body
@@ -25,11 +29,11 @@ type Alien struct {
 }
 
 // NewAlien with random characteristics
-func NewAlien() Alien {
+func NewAlien(rng *dice.Rand) Alien {
 	a := Alien{
-		Body:            alienTable.body.Roll(),
-		Lense:           alienTable.lense.Roll(),
-		SocialStructure: alienTable.socialStructure.Roll(),
+		Body:            rng.Roll(alienTable.body),
+		Lense:           rng.Roll(alienTable.lense),
+		SocialStructure: rng.Roll(alienTable.socialStructure),
 	}
 	return a
 }
@@ -67,22 +71,7 @@ var alienTable = struct {
 			{Match: []int{3}, Text: "Insectile, beetle-like, spiderish, wasp-like"},
 			{Match: []int{4}, Text: "Mammalian, furred or bare-skinned"},
 			{Match: []int{5}, Text: "Exotic, composed of some novel substance"},
-			{Match: []int{6}, Text: "Hybrid of two or more types", Action: func() string {
-				tbl, _ := table.Registry.Get("alien.Body")
-				tbl.Dice = roll.Dice{N: 1, Die: dice.D5}
-
-				types, res := 2+rand.Intn(4), make(map[string]bool)
-				for len(res) < types {
-					res[tbl.Roll()] = true
-				}
-
-				text := []string{}
-				for k := range res {
-					text = append(text, k)
-				}
-
-				return "\n\t\t" + strings.Join(text, "\n\t\t")
-			}},
+			{Match: []int{6}, Text: "Hybrid of two or more types"},
 		},
 	},
 
@@ -124,25 +113,45 @@ var alienTable = struct {
 			{Match: []int{2}, Text: "Monarchic"},
 			{Match: []int{3}, Text: "Tribal"},
 			{Match: []int{4}, Text: "Oligarchic"},
-			{Match: []int{5, 6}, Text: "Multipolar Competitive", Action: actionSocialStructure},
-			{Match: []int{7, 8}, Text: "Multipolar Cooperative", Action: actionSocialStructure},
+			{Match: []int{5, 6}, Text: "Multipolar Competitive"},
+			{Match: []int{7, 8}, Text: "Multipolar Cooperative"},
 		},
 	},
 }
 
-func actionSocialStructure() string {
+// actionHybridBody rolls the types of a hybrid alien Body
+func actionHybridBody(rng *dice.Rand) string {
+	tbl, _ := table.Registry.Get("alien.Body")
+	tbl.Dice = roll.Dice{N: 1, Die: dice.D5}
+
+	types, res := 2+rng.Intn(4), make(map[string]bool)
+	for len(res) < types {
+		res[rng.Roll(tbl)] = true
+	}
+
+	text := []string{}
+	for k := range res {
+		text = append(text, k)
+	}
+	sort.Strings(text) // Map order is random, sort it so that seeded output is reproducible
+
+	return "\n\t\t" + strings.Join(text, "\n\t\t")
+}
+
+func actionSocialStructure(rng *dice.Rand) string {
 	tbl, _ := table.Registry.Get("alien.SocialStructure")
 	tbl.Dice = roll.Dice{N: 1, Die: roll.D4}
 
-	types, res := 2+rand.Intn(3), make(map[string]bool)
+	types, res := 2+rng.Intn(3), make(map[string]bool)
 	for len(res) < types {
-		res[tbl.Roll()] = true
+		res[rng.Roll(tbl)] = true
 	}
 
 	text := []string{}
 	for k := range res {
 		text = append(text, k)
 	}
+	sort.Strings(text)
 
 	return strings.Join(text, ", ")
 }
@@ -3,7 +3,7 @@ package content
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
+	"sort"
 	"strings"
 
 	"github.com/nboughton/go-roll"
@@ -15,6 +15,12 @@ import (
 func init() {
 	table.Registry.Add(beastFeaturesTable.basicFeatures)
 	table.Registry.Add(beastFeaturesTable.bodyPlan)
+
+	dice.AddAction("beast.BasicFeatures", "Mixed", actionMixedFeatures)
+	dice.AddAction("beast.BodyPlan", "", actionMixedBodyPlan)
+	dice.AddAction("beast.MainWeapon", "Poison", poisonAction)
+	dice.AddAction("beast.MainWeapon", "Harmful discharge", actionHarmfulDischarge)
+	dice.AddAction("beast.HarmfulDischarges", "Toxic spittle or cloud; ", poisonAction)
 }
 
 // Beast defines the aggregate descriptors for an animal
@@ -30,28 +36,28 @@ type Beast struct {
 }
 
 // NewBeast for terrorising players
-func NewBeast() Beast {
+func NewBeast(rng *dice.Rand) Beast {
 	b := Beast{
-		Features:    beastFeaturesTable.basicFeatures.Roll(),
-		BodyPlan:    beastFeaturesTable.bodyPlan.Roll(),
-		LimbNovelty: beastFeaturesTable.limbNovelty.Roll(),
-		SkinNovelty: beastFeaturesTable.skinNovelty.Roll(),
-		MainWeapon:  beastFeaturesTable.mainWeapon.Roll(),
-		Size:        beastFeaturesTable.size.Roll(),
+		Features:    rng.Roll(beastFeaturesTable.basicFeatures),
+		BodyPlan:    rng.Roll(beastFeaturesTable.bodyPlan),
+		LimbNovelty: rng.Roll(beastFeaturesTable.limbNovelty),
+		SkinNovelty: rng.Roll(beastFeaturesTable.skinNovelty),
+		MainWeapon:  rng.Roll(beastFeaturesTable.mainWeapon),
+		Size:        rng.Roll(beastFeaturesTable.size),
 	}
 
-	switch rand.Intn(3) {
+	switch rng.Intn(3) {
 	case 0:
 		b.Type = beastBehaviourTable.predator.Label()
-		b.Behaviour = beastBehaviourTable.predator.Roll()
+		b.Behaviour = rng.Roll(beastBehaviourTable.predator)
 
 	case 1:
 		b.Type = beastBehaviourTable.prey.Label()
-		b.Behaviour = beastBehaviourTable.prey.Roll()
+		b.Behaviour = rng.Roll(beastBehaviourTable.prey)
 
 	case 2:
 		b.Type = beastBehaviourTable.scavenger.Label()
-		b.Behaviour = beastBehaviourTable.scavenger.Roll()
+		b.Behaviour = rng.Roll(beastBehaviourTable.scavenger)
 	}
 
 	return b
@@ -100,22 +106,7 @@ var beastFeaturesTable = struct {
 			{Match: []int{6}, Text: "Reptile, lizardlike and long-bodied"},
 			{Match: []int{7}, Text: "Spider, many-legged and fat"},
 			{Match: []int{8}, Text: "Exotic, made of wholly alien elements"},
-			{Match: []int{9, 10}, Text: "Mixed", Action: func() string {
-				tbl, _ := table.Registry.Get("beast.BasicFeatures")
-				tbl.Dice = roll.Dice{N: 1, Die: roll.D8}
-
-				res := make(map[string]bool)
-				for len(res) < 2 {
-					res[tbl.Roll()] = true
-				}
-
-				text := []string{}
-				for k := range res {
-					text = append(text, k)
-				}
-
-				return strings.Join(text, " and ")
-			}},
+			{Match: []int{9, 10}, Text: "Mixed"},
 		},
 	},
 
@@ -130,22 +121,7 @@ var beastFeaturesTable = struct {
 			{Match: []int{3}, Text: "Many-legged"},
 			{Match: []int{4}, Text: "Bulbous"},
 			{Match: []int{5}, Text: "Amorphous"},
-			{Match: []int{6}, Text: "", Action: func() string {
-				tbl, _ := table.Registry.Get("beast.BodyPlan")
-				tbl.Dice = roll.Dice{N: 1, Die: dice.D5}
-
-				types, res := 2, make(map[string]bool)
-				for len(res) < types {
-					res[tbl.Roll()] = true
-				}
-
-				text := []string{}
-				for k := range res {
-					text = append(text, k)
-				}
-
-				return strings.Join(text, " and ")
-			}},
+			{Match: []int{6}, Text: ""},
 		},
 	},
 
@@ -187,10 +163,8 @@ var beastFeaturesTable = struct {
 		Items: []roll.TableItem{
 			{Match: []int{1}, Text: "Teeth or mandibles"},
 			{Match: []int{2}, Text: "Claws"},
-			{Match: []int{3}, Text: "Poison", Action: poisonAction},
-			{Match: []int{4}, Text: "Harmful discharge", Action: func() string {
-				return harmfulDischargesTable.Roll()
-			}},
+			{Match: []int{3}, Text: "Poison"},
+			{Match: []int{4}, Text: "Harmful discharge"},
 			{Match: []int{5}, Text: "Pincers"},
 			{Match: []int{6}, Text: "Horns"},
 		},
@@ -278,7 +252,7 @@ var harmfulDischargesTable = roll.Table{
 	Dice: roll.Dice{N: 1, Die: roll.D8},
 	Items: []roll.TableItem{
 		{Match: []int{1}, Text: "Acidic spew doing its damage on a hit"},
-		{Match: []int{2}, Text: "Toxic spittle or cloud; ", Action: poisonAction},
+		{Match: []int{2}, Text: "Toxic spittle or cloud; "},
 		{Match: []int{3}, Text: "Super-heated or super-chilled spew"},
 		{Match: []int{4}, Text: "Sonic drill or other disabling noise"},
 		{Match: []int{5}, Text: "Natural laser or plasma discharge"},
@@ -337,9 +311,51 @@ var poisonTable = struct {
 	},
 }
 
-func poisonAction() string {
+// actionMixedFeatures rolls the basic features of a Beast with mixed features
+func actionMixedFeatures(rng *dice.Rand) string {
+	tbl, _ := table.Registry.Get("beast.BasicFeatures")
+	tbl.Dice = roll.Dice{N: 1, Die: roll.D8}
+
+	res := make(map[string]bool)
+	for len(res) < 2 {
+		res[rng.Roll(tbl)] = true
+	}
+
+	text := []string{}
+	for k := range res {
+		text = append(text, k)
+	}
+	sort.Strings(text)
+
+	return strings.Join(text, " and ")
+}
+
+// actionMixedBodyPlan rolls the body plans of a Beast with a mixed body plan
+func actionMixedBodyPlan(rng *dice.Rand) string {
+	tbl, _ := table.Registry.Get("beast.BodyPlan")
+	tbl.Dice = roll.Dice{N: 1, Die: dice.D5}
+
+	types, res := 2, make(map[string]bool)
+	for len(res) < types {
+		res[rng.Roll(tbl)] = true
+	}
+
+	text := []string{}
+	for k := range res {
+		text = append(text, k)
+	}
+	sort.Strings(text)
+
+	return strings.Join(text, " and ")
+}
+
+func actionHarmfulDischarge(rng *dice.Rand) string {
+	return rng.Roll(harmfulDischargesTable)
+}
+
+func poisonAction(rng *dice.Rand) string {
 	return fmt.Sprintf("in %s the target suffers from %s over %s.",
-		poisonTable.onset.Roll(),
-		poisonTable.effect.Roll(),
-		poisonTable.duration.Roll())
+		rng.Roll(poisonTable.onset),
+		rng.Roll(poisonTable.effect),
+		rng.Roll(poisonTable.duration))
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Conflict is pretty self explanatory
@@ -17,11 +18,11 @@ type Conflict struct {
 }
 
 // NewConflict for fun and profit
-func NewConflict() Conflict {
+func NewConflict(rng *dice.Rand) Conflict {
 	return Conflict{
-		Restraint: conflictTable.restraint.Roll(),
-		Twist:     conflictTable.twist.Roll(),
-		Problem:   conflictTable.problem.Roll(),
+		Restraint: rng.Roll(conflictTable.restraint),
+		Twist:     rng.Roll(conflictTable.twist),
+		Problem:   conflictTable.problem.Roll(rng),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Corporation with a Body
@@ -17,12 +18,12 @@ type Corporation struct {
 }
 
 // NewCorporation with random characteristics
-func NewCorporation() Corporation {
+func NewCorporation(rng *dice.Rand) Corporation {
 	c := Corporation{
-		Name:               corpTable.name.Roll(),
-		Organization:       corpTable.organization.Roll(),
-		Business:           corpTable.business.Roll(),
-		ReputationAndRumor: corpTable.reputation.Roll(),
+		Name:               rng.Roll(corpTable.name),
+		Organization:       rng.Roll(corpTable.organization),
+		Business:           rng.Roll(corpTable.business),
+		ReputationAndRumor: rng.Roll(corpTable.reputation),
 	}
 	return c
 }
@@ -3,14 +3,10 @@ package culture
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
-	"time"
-)
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
+	"github.com/nboughton/swnt/dice"
+)
 
 // Culture represents the name of supported culture
 type Culture string
@@ -34,15 +30,16 @@ const (
 var Cultures = []Culture{Arabic, Chinese, English, Greek, Indian, Japanese, Latin, Nigerian, Russian, Spanish}
 
 // Random returns a cultures' identifier and string name at random
-func Random() Culture {
-	n := rand.Intn(len(Cultures))
+func Random(rng *dice.Rand) Culture {
+	n := rng.Intn(len(Cultures))
 	return Cultures[n]
 }
 
-// Find returns the correct constant or an error if it does not exist
-func Find(name string) (Culture, error) {
+// Find returns the correct constant or an error if it does not exist. Any (or no name) returns a
+// random Culture drawn from rng.
+func Find(rng *dice.Rand, name string) (Culture, error) {
 	if strings.ToLower(name) == strings.ToLower(Any.String()) || name == "" {
-		return Random(), nil
+		return Random(rng), nil
 	}
 
 	for _, c := range Cultures {
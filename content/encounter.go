@@ -4,6 +4,7 @@ import (
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Encounter represents an encounter
@@ -13,17 +14,17 @@ type Encounter struct {
 }
 
 // NewEncounter creates a new encounter
-func NewEncounter(wilderness bool) Encounter {
+func NewEncounter(rng *dice.Rand, wilderness bool) Encounter {
 	if wilderness {
 		return Encounter{
 			Type:   "Wilderness",
-			Fields: wildernessEncounterTable.Roll(),
+			Fields: wildernessEncounterTable.Roll(rng),
 		}
 	}
 
 	return Encounter{
 		Type:   "Urban",
-		Fields: urbanEncounterTable.Roll(),
+		Fields: urbanEncounterTable.Roll(rng),
 	}
 }
 
@@ -0,0 +1,375 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/dice"
+)
+
+// Faction attributes
+const (
+	Force   = "Force"
+	Cunning = "Cunning"
+	Wealth  = "Wealth"
+)
+
+// FactionSize determines the starting ratings and assets of a new Faction
+type FactionSize string
+
+// FactionSize constants
+const (
+	MinorFaction   FactionSize = "Minor"
+	MajorFaction   FactionSize = "Major"
+	HegemonFaction FactionSize = "Hegemon"
+)
+
+// FactionSizes supported
+var FactionSizes = []FactionSize{MinorFaction, MajorFaction, HegemonFaction}
+
+// FindFactionSize returns the FactionSize named n or a random size if n is empty
+func FindFactionSize(rng *dice.Rand, n string) (FactionSize, error) {
+	if n == "" {
+		return FactionSizes[rng.Intn(len(FactionSizes))], nil
+	}
+
+	for _, s := range FactionSizes {
+		if strings.ToLower(string(s)) == strings.ToLower(n) {
+			return s, nil
+		}
+	}
+
+	return "", fmt.Errorf("no faction size found for \"%s\", options available are %s", n, FactionSizes)
+}
+
+// Faction represents a faction as used by the faction rules of Stars Without Number (Revised Edition)
+type Faction struct {
+	Name      string
+	Size      FactionSize
+	Homeworld string
+	Force     int
+	Cunning   int
+	Wealth    int
+	HP        int
+	MaxHP     int
+	FacCreds  int
+	XP        int
+	Goal      FactionGoal
+	Assets    []FactionAsset
+}
+
+// FactionAsset is an asset owned by a Faction
+type FactionAsset struct {
+	Name      string
+	Attribute string // Attribute the asset is bought with
+	Rating    int    // Rating of Attribute required to buy the asset
+	HP        int
+	MaxHP     int
+	Cost      int
+	Attack    string // Attacking and defending attributes and damage, i.e "Force vs Cunning 1d6". Blank if the asset can't attack
+	Counter   string // Counterattack damage, blank if the asset has none
+	Location  string
+}
+
+// NewFaction creates a new Faction of size s based on homeworld. If homeworld is blank the Faction is
+// given one from the name tables.
+func NewFaction(rng *dice.Rand, s FactionSize, homeworld string) Faction {
+	attrs := []string{Force, Cunning, Wealth}
+	rng.Shuffle(len(attrs), func(i, j int) { attrs[i], attrs[j] = attrs[j], attrs[i] })
+
+	ratings, assets := [3]int{4, 3, 1}, 2
+	switch s {
+	case MajorFaction:
+		ratings, assets = [3]int{6, 5, 3}, 4
+	case HegemonFaction:
+		ratings, assets = [3]int{8, 7, 5}, 6
+	}
+
+	if homeworld == "" {
+		homeworld = rng.Roll(factionTable.homeworld)
+	}
+
+	f := Faction{
+		Name:      fmt.Sprintf("%s %s", rng.Roll(factionTable.adjective), rng.Roll(factionTable.noun)),
+		Size:      s,
+		Homeworld: homeworld,
+	}
+
+	for i, a := range attrs {
+		f.setRating(a, ratings[i])
+	}
+
+	f.MaxHP = f.maxHP()
+	f.HP = f.MaxHP
+	f.FacCreds = f.Income()
+	f.Goal = f.newGoal(rng)
+
+	// Starting assets are weighted towards the faction's strongest attribute
+	for i := 0; i < assets; i++ {
+		a := attrs[0]
+		if i%2 != 0 {
+			a = attrs[rng.Intn(len(attrs))]
+		}
+
+		if asset, ok := f.randomAsset(rng, a, 0); ok {
+			f.Assets = append(f.Assets, asset)
+		}
+	}
+
+	return f
+}
+
+// Rating returns the faction's rating in attribute a
+func (f Faction) Rating(a string) int {
+	switch a {
+	case Force:
+		return f.Force
+	case Cunning:
+		return f.Cunning
+	case Wealth:
+		return f.Wealth
+	}
+
+	return 0
+}
+
+func (f *Faction) setRating(a string, n int) {
+	switch a {
+	case Force:
+		f.Force = n
+	case Cunning:
+		f.Cunning = n
+	case Wealth:
+		f.Wealth = n
+	}
+}
+
+// maxHP is 4 plus the experience cost of each attribute rating
+func (f Faction) maxHP() int {
+	hp := []int{0, 1, 2, 4, 6, 9, 12, 16, 20}
+	return 4 + hp[f.Force] + hp[f.Cunning] + hp[f.Wealth]
+}
+
+// Income returns the FacCreds earned by the faction each turn
+func (f Faction) Income() int {
+	return (f.Wealth+1)/2 + (f.Force+f.Cunning)/4
+}
+
+// randomAsset returns a random asset of attribute a that the faction can field. If budget is above
+// 0 the asset must cost no more than budget.
+func (f Faction) randomAsset(rng *dice.Rand, a string, budget int) (FactionAsset, bool) {
+	var options []FactionAsset
+	for _, asset := range factionAssets {
+		if asset.Attribute == a && asset.Rating <= f.Rating(a) && (budget <= 0 || asset.Cost <= budget) {
+			options = append(options, asset)
+		}
+	}
+
+	if len(options) == 0 {
+		return FactionAsset{}, false
+	}
+
+	asset := options[rng.Intn(len(options))]
+	asset.HP, asset.Location = asset.MaxHP, f.Homeworld
+
+	return asset, true
+}
+
+// Format returns the faction formatted as type t
+func (f Faction) Format(t format.OutputType) string {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintf(buf, format.Table(t, []string{f.Name, ""}, [][]string{
+		{"Size", string(f.Size)},
+		{"Homeworld", f.Homeworld},
+		{Force, strconv.Itoa(f.Force)},
+		{Cunning, strconv.Itoa(f.Cunning)},
+		{Wealth, strconv.Itoa(f.Wealth)},
+		{"HP", fmt.Sprintf("%d/%d", f.HP, f.MaxHP)},
+		{"FacCreds", fmt.Sprintf("%d (+%d per turn)", f.FacCreds, f.Income())},
+		{"XP", strconv.Itoa(f.XP)},
+		{"Goal", fmt.Sprintf("%s (%d/%d)", f.Goal.Name, f.Goal.Progress, f.Goal.Target)},
+		{"", f.Goal.Desc},
+	}))
+
+	if len(f.Assets) > 0 {
+		var rows [][]string
+		for _, a := range f.Assets {
+			rows = append(rows, []string{a.Name, fmt.Sprintf("%s %d", a.Attribute, a.Rating), fmt.Sprintf("%d/%d", a.HP, a.MaxHP), a.Attack, a.Counter, a.Location})
+		}
+
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, format.Table(t, []string{"Asset", "Type", "HP", "Attack", "Counter", "Location"}, rows))
+	}
+
+	return buf.String()
+}
+
+func (f Faction) String() string {
+	return f.Format(format.TEXT)
+}
+
+var damageDice = regexp.MustCompile(`^(\d+)d(\d+)([+-]\d+)?$`)
+
+func isAttribute(a string) bool {
+	return a == Force || a == Cunning || a == Wealth
+}
+
+// attackRolls splits the asset's Attack into the attribute it rolls, the attribute the defender rolls
+// and the damage it deals
+func (a FactionAsset) attackRolls() (atk, def, dmg string, err error) {
+	f := strings.Fields(a.Attack)
+	if len(f) != 4 || f[1] != "vs" {
+		return "", "", "", fmt.Errorf("%s has attack %q, want the form \"Force vs Cunning 1d6\"", a.Name, a.Attack)
+	}
+
+	for _, attr := range []string{f[0], f[2]} {
+		if !isAttribute(attr) {
+			return "", "", "", fmt.Errorf("%s has attack %q, %q is not one of %s, %s or %s", a.Name, a.Attack, attr, Force, Cunning, Wealth)
+		}
+	}
+
+	if !damageDice.MatchString(f[3]) {
+		return "", "", "", fmt.Errorf("%s has attack %q, %q is not a dice expression such as 1d6+1", a.Name, a.Attack, f[3])
+	}
+
+	return f[0], f[2], f[3], nil
+}
+
+// Validate checks that every asset's attack and counterattack can be rolled
+func (a FactionAsset) Validate() error {
+	if a.Attack != "" {
+		if _, _, _, err := a.attackRolls(); err != nil {
+			return err
+		}
+	}
+
+	if a.Counter != "" && !damageDice.MatchString(a.Counter) {
+		return fmt.Errorf("%s has counter %q, want a dice expression such as 1d6+1", a.Name, a.Counter)
+	}
+
+	return nil
+}
+
+// rollDamage rolls a damage expression such as "2d4+2"
+func rollDamage(rng *dice.Rand, s string) int {
+	m := damageDice.FindStringSubmatch(s)
+	if m == nil {
+		return 0
+	}
+
+	n, _ := strconv.Atoi(m[1])
+	sides, _ := strconv.Atoi(m[2])
+	if sides < 1 {
+		return 0
+	}
+
+	dmg := 0
+	for i := 0; i < n; i++ {
+		dmg += rng.Intn(sides) + 1
+	}
+
+	mod, _ := strconv.Atoi(m[3])
+	if n := dmg + mod; n > 0 {
+		return n
+	}
+
+	return 0
+}
+
+var factionTable = struct {
+	adjective roll.List
+	noun      roll.List
+	homeworld roll.List
+}{
+	roll.List{
+		Name: "Adjective",
+		Items: []string{
+			"Argent", "Ascendant", "Azure", "Black", "Crimson", "Eternal", "Free", "Golden", "Hidden", "Iron",
+			"Radiant", "Red", "Silent", "Sovereign", "Starlit", "United", "Veiled", "Void",
+		},
+	},
+	roll.List{
+		Name: "Noun",
+		Items: []string{
+			"Assembly", "Cabal", "Cartel", "Circle", "Collective", "Combine", "Compact", "Concord", "Covenant",
+			"Directorate", "Front", "Hegemony", "League", "Mandate", "Syndicate", "Union",
+		},
+	},
+	roll.List{
+		Name: "Homeworld",
+		Items: []string{
+			"Avalon", "Caliban", "Cerberus", "Dis", "Erebus", "Gaia", "Hesperus", "Janus", "Lethe", "Meridian",
+			"Nyx", "Prospero", "Tethys", "Themis", "Typhon", "Zephyr",
+		},
+	},
+}
+
+// factionAssets lists the assets available to factions by attribute and required rating
+var factionAssets = []FactionAsset{
+	{Name: "Security Personnel", Attribute: Force, Rating: 1, MaxHP: 3, Cost: 2, Attack: "Force vs Force 1d3+1", Counter: "1d4"},
+	{Name: "Hitmen", Attribute: Force, Rating: 1, MaxHP: 1, Cost: 2, Attack: "Force vs Cunning 1d6"},
+	{Name: "Militia Unit", Attribute: Force, Rating: 1, MaxHP: 4, Cost: 4, Attack: "Force vs Force 1d6", Counter: "1d4+1"},
+	{Name: "Heavy Drop Assets", Attribute: Force, Rating: 2, MaxHP: 6, Cost: 4},
+	{Name: "Elite Skirmishers", Attribute: Force, Rating: 2, MaxHP: 5, Cost: 5, Attack: "Force vs Force 2d4", Counter: "1d4+1"},
+	{Name: "Hardened Personnel", Attribute: Force, Rating: 2, MaxHP: 4, Cost: 4, Counter: "1d4+1"},
+	{Name: "Guerrilla Populace", Attribute: Force, Rating: 2, MaxHP: 6, Cost: 4, Attack: "Force vs Force 1d4+1"},
+	{Name: "Zealots", Attribute: Force, Rating: 3, MaxHP: 4, Cost: 6, Attack: "Force vs Force 2d6", Counter: "2d6"},
+	{Name: "Cunning Trap", Attribute: Force, Rating: 3, MaxHP: 2, Cost: 5, Counter: "1d6+3"},
+	{Name: "Beachhead Landers", Attribute: Force, Rating: 4, MaxHP: 10, Cost: 10},
+	{Name: "Strike Fleet", Attribute: Force, Rating: 4, MaxHP: 8, Cost: 12, Attack: "Force vs Force 2d6", Counter: "1d8"},
+	{Name: "Postech Infantry", Attribute: Force, Rating: 5, MaxHP: 16, Cost: 20, Attack: "Force vs Force 1d8", Counter: "1d8"},
+	{Name: "Blockade Fleet", Attribute: Force, Rating: 5, MaxHP: 8, Cost: 10, Attack: "Force vs Wealth 1d6"},
+	{Name: "Pretech Logistics", Attribute: Force, Rating: 6, MaxHP: 6, Cost: 14},
+	{Name: "Psychic Assassins", Attribute: Force, Rating: 6, MaxHP: 4, Cost: 12, Attack: "Force vs Cunning 2d6+2"},
+	{Name: "Pretech Infantry", Attribute: Force, Rating: 7, MaxHP: 20, Cost: 30, Attack: "Force vs Force 2d8", Counter: "2d8"},
+	{Name: "Planetary Defenses", Attribute: Force, Rating: 7, MaxHP: 20, Cost: 18, Counter: "2d6+6"},
+	{Name: "Gravtank Formation", Attribute: Force, Rating: 7, MaxHP: 14, Cost: 25, Attack: "Force vs Force 2d10+4", Counter: "1d10"},
+	{Name: "Deep Strike Landers", Attribute: Force, Rating: 8, MaxHP: 10, Cost: 25},
+	{Name: "Space Marines", Attribute: Force, Rating: 8, MaxHP: 16, Cost: 30, Attack: "Force vs Force 2d8+2", Counter: "2d8"},
+
+	{Name: "Smugglers", Attribute: Cunning, Rating: 1, MaxHP: 4, Cost: 2, Attack: "Cunning vs Wealth 1d4"},
+	{Name: "Informers", Attribute: Cunning, Rating: 1, MaxHP: 3, Cost: 2},
+	{Name: "False Front", Attribute: Cunning, Rating: 1, MaxHP: 2, Cost: 1},
+	{Name: "Lobbyists", Attribute: Cunning, Rating: 2, MaxHP: 4, Cost: 4},
+	{Name: "Saboteurs", Attribute: Cunning, Rating: 2, MaxHP: 6, Cost: 5, Attack: "Cunning vs Wealth 2d4"},
+	{Name: "Blackmail", Attribute: Cunning, Rating: 2, MaxHP: 4, Cost: 6, Attack: "Cunning vs Cunning 1d4"},
+	{Name: "Cyberninjas", Attribute: Cunning, Rating: 3, MaxHP: 4, Cost: 6, Attack: "Cunning vs Cunning 2d6"},
+	{Name: "Covert Shipping", Attribute: Cunning, Rating: 3, MaxHP: 4, Cost: 8},
+	{Name: "Party Machine", Attribute: Cunning, Rating: 4, MaxHP: 10, Cost: 10, Attack: "Cunning vs Cunning 2d6", Counter: "1d6"},
+	{Name: "Vanguard Cadres", Attribute: Cunning, Rating: 4, MaxHP: 12, Cost: 8, Attack: "Cunning vs Cunning 1d6", Counter: "1d6"},
+	{Name: "Tripwire Cells", Attribute: Cunning, Rating: 4, MaxHP: 8, Cost: 12, Counter: "1d4"},
+	{Name: "Organization Moles", Attribute: Cunning, Rating: 5, MaxHP: 10, Cost: 10, Attack: "Cunning vs Cunning 2d6"},
+	{Name: "Boltholes", Attribute: Cunning, Rating: 5, MaxHP: 6, Cost: 12, Counter: "2d6"},
+	{Name: "Covert Transit Net", Attribute: Cunning, Rating: 6, MaxHP: 15, Cost: 18},
+	{Name: "Demagogue", Attribute: Cunning, Rating: 6, MaxHP: 10, Cost: 20, Attack: "Cunning vs Cunning 2d8", Counter: "1d8"},
+	{Name: "Popular Movement", Attribute: Cunning, Rating: 7, MaxHP: 16, Cost: 25, Attack: "Cunning vs Cunning 2d6", Counter: "1d6"},
+	{Name: "Book of Secrets", Attribute: Cunning, Rating: 7, MaxHP: 10, Cost: 20, Counter: "2d8"},
+	{Name: "Panopticon Matrix", Attribute: Cunning, Rating: 8, MaxHP: 20, Cost: 30, Counter: "1d6"},
+
+	{Name: "Franchise", Attribute: Wealth, Rating: 1, MaxHP: 3, Cost: 2, Attack: "Wealth vs Wealth 1d4", Counter: "1d4-1"},
+	{Name: "Harvesters", Attribute: Wealth, Rating: 1, MaxHP: 4, Cost: 2, Counter: "1d4"},
+	{Name: "Local Investments", Attribute: Wealth, Rating: 1, MaxHP: 2, Cost: 1, Attack: "Wealth vs Wealth 1d4-1"},
+	{Name: "Freighter Contract", Attribute: Wealth, Rating: 2, MaxHP: 4, Cost: 5, Attack: "Wealth vs Wealth 1d4"},
+	{Name: "Lawyers", Attribute: Wealth, Rating: 2, MaxHP: 4, Cost: 6, Attack: "Wealth vs Wealth 2d4", Counter: "1d6"},
+	{Name: "Union Toughs", Attribute: Wealth, Rating: 2, MaxHP: 6, Cost: 4, Attack: "Wealth vs Force 1d4+1", Counter: "1d4"},
+	{Name: "Surveyors", Attribute: Wealth, Rating: 2, MaxHP: 4, Cost: 4, Counter: "1d4"},
+	{Name: "Postech Industry", Attribute: Wealth, Rating: 3, MaxHP: 4, Cost: 8, Counter: "1d4"},
+	{Name: "Laboratory", Attribute: Wealth, Rating: 3, MaxHP: 4, Cost: 6},
+	{Name: "Mercenaries", Attribute: Wealth, Rating: 3, MaxHP: 6, Cost: 8, Attack: "Wealth vs Force 2d4+2", Counter: "1d6"},
+	{Name: "Shipping Combine", Attribute: Wealth, Rating: 4, MaxHP: 10, Cost: 10, Counter: "1d6"},
+	{Name: "Monopoly", Attribute: Wealth, Rating: 4, MaxHP: 12, Cost: 8, Attack: "Wealth vs Wealth 1d6", Counter: "1d6"},
+	{Name: "Medical Center", Attribute: Wealth, Rating: 4, MaxHP: 8, Cost: 12},
+	{Name: "Marketers", Attribute: Wealth, Rating: 5, MaxHP: 8, Cost: 10, Attack: "Wealth vs Cunning 1d6"},
+	{Name: "Blockade Runners", Attribute: Wealth, Rating: 5, MaxHP: 6, Cost: 12, Counter: "2d4"},
+	{Name: "Venture Capital", Attribute: Wealth, Rating: 6, MaxHP: 10, Cost: 15, Attack: "Wealth vs Wealth 2d6", Counter: "1d6"},
+	{Name: "Commodities Broker", Attribute: Wealth, Rating: 6, MaxHP: 10, Cost: 20, Attack: "Wealth vs Wealth 2d8", Counter: "1d8"},
+	{Name: "Pretech Manufactory", Attribute: Wealth, Rating: 7, MaxHP: 16, Cost: 25},
+	{Name: "Hostile Takeover", Attribute: Wealth, Rating: 7, MaxHP: 10, Cost: 20, Attack: "Wealth vs Wealth 2d10", Counter: "2d8"},
+	{Name: "Scavenger Fleet", Attribute: Wealth, Rating: 8, MaxHP: 20, Cost: 30, Attack: "Wealth vs Wealth 2d10+4", Counter: "2d10"},
+}
@@ -0,0 +1,379 @@
+package content
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nboughton/swnt/dice"
+)
+
+// Faction goals supported by the faction turn
+const (
+	MilitaryConquest    = "Military Conquest"
+	CommercialExpansion = "Commercial Expansion"
+	IntelligenceCoup    = "Intelligence Coup"
+	BloodTheEnemy       = "Blood the Enemy"
+	PeaceableKingdom    = "Peaceable Kingdom"
+	WealthOfWorlds      = "Wealth of Worlds"
+	InvincibleValor     = "Invincible Valor"
+)
+
+// FactionGoal is the current objective of a Faction. Difficulty is the XP awarded on completion.
+type FactionGoal struct {
+	Name       string
+	Desc       string
+	Target     int
+	Progress   int
+	Difficulty int
+}
+
+func half(n int) int {
+	if n < 2 {
+		return 1
+	}
+
+	return n / 2
+}
+
+// newGoal selects a random goal scaled to the faction's current ratings
+func (f Faction) newGoal(rng *dice.Rand) FactionGoal {
+	goals := []FactionGoal{
+		{Name: MilitaryConquest, Desc: "Destroy Force assets of rival factions equal to the faction's Force rating", Target: f.Force, Difficulty: half(f.Force)},
+		{Name: CommercialExpansion, Desc: "Buy new Wealth assets equal to the faction's Wealth rating", Target: f.Wealth, Difficulty: half(f.Wealth)},
+		{Name: IntelligenceCoup, Desc: "Destroy Cunning assets of rival factions equal to the faction's Cunning rating", Target: f.Cunning, Difficulty: half(f.Cunning)},
+		{Name: BloodTheEnemy, Desc: "Inflict damage on rival factions equal to the faction's total ratings", Target: f.Force + f.Cunning + f.Wealth, Difficulty: 2},
+		{Name: PeaceableKingdom, Desc: "Don't take an Attack action for four turns", Target: 4, Difficulty: 1},
+		{Name: WealthOfWorlds, Desc: "Spend FacCreds equal to four times the faction's Wealth rating", Target: 4 * f.Wealth, Difficulty: 2},
+		{Name: InvincibleValor, Desc: "Destroy a Force asset with a rating higher than the faction's Force rating", Target: 1, Difficulty: 2},
+	}
+
+	return goals[rng.Intn(len(goals))]
+}
+
+// advanceGoal adds n progress to the faction's goal if it is of type goal
+func (f *Faction) advanceGoal(goal string, n int) {
+	if f.Goal.Name == goal {
+		f.Goal.Progress += n
+	}
+}
+
+// FactionRoster is a collection of Factions that act against each other in faction turns
+type FactionRoster struct {
+	Turn     int
+	Factions []Faction
+}
+
+// Find returns the index of the faction named n in the roster or -1 if there is no such faction
+func (r *FactionRoster) Find(n string) int {
+	for i, f := range r.Factions {
+		if strings.ToLower(f.Name) == strings.ToLower(n) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Validate checks that the assets of every faction in the roster can be used in a faction turn
+func (r *FactionRoster) Validate() error {
+	for _, f := range r.Factions {
+		for _, a := range f.Assets {
+			if err := a.Validate(); err != nil {
+				return fmt.Errorf("%s: %s", f.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RunTurn runs a single faction turn and returns a log of what happened. Each faction, in a random
+// order, earns income, takes one action (attack, buy an asset or repair) and then checks its goal.
+// Factions reduced to 0 HP are removed from the roster.
+func (r *FactionRoster) RunTurn(rng *dice.Rand) []string {
+	r.Turn++
+	log := []string{fmt.Sprintf("Turn %d", r.Turn)}
+
+	for _, i := range rng.Perm(len(r.Factions)) {
+		f := &r.Factions[i]
+		if f.HP <= 0 {
+			continue
+		}
+
+		// Income
+		income := f.Income() - f.upkeep()
+		f.FacCreds += income
+		if f.FacCreds < 0 {
+			f.FacCreds = 0
+		}
+		log = append(log, fmt.Sprintf("%s earns %d FacCreds and now has %d", f.Name, income, f.FacCreds))
+
+		// Action
+		attacked := false
+		switch {
+		case f.HP <= f.MaxHP/2:
+			log = append(log, f.repair(rng))
+
+		case f.Goal.Name != PeaceableKingdom && r.rivals(i) && f.attacker(rng) >= 0 && rng.Intn(2) == 0:
+			log = append(log, r.attack(rng, i)...)
+			attacked = true
+
+		case f.canBuy():
+			log = append(log, f.buy(rng))
+
+		case f.damaged():
+			log = append(log, f.repair(rng))
+
+		default:
+			log = append(log, fmt.Sprintf("%s hoards its FacCreds", f.Name))
+		}
+
+		if !attacked {
+			f.advanceGoal(PeaceableKingdom, 1)
+		}
+
+		// Goal check
+		if f.Goal.Progress >= f.Goal.Target {
+			f.XP += f.Goal.Difficulty
+			log = append(log, fmt.Sprintf("%s completes its goal of %s and gains %d XP", f.Name, f.Goal.Name, f.Goal.Difficulty))
+			f.Goal = f.newGoal(rng)
+			log = append(log, fmt.Sprintf("%s takes up a new goal: %s", f.Name, f.Goal.Name))
+		}
+	}
+
+	var survivors []Faction
+	for _, f := range r.Factions {
+		if f.HP > 0 {
+			survivors = append(survivors, f)
+		} else {
+			log = append(log, fmt.Sprintf("%s has been destroyed", f.Name))
+		}
+	}
+	r.Factions = survivors
+
+	return log
+}
+
+// upkeep is 1 FacCred for each asset that requires a higher rating than the faction has
+func (f Faction) upkeep() int {
+	n := 0
+	for _, a := range f.Assets {
+		if a.Rating > f.Rating(a.Attribute) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// rivals reports whether there are any other factions left for faction i to attack
+func (r *FactionRoster) rivals(i int) bool {
+	for j, f := range r.Factions {
+		if j != i && f.HP > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// attacker returns the index of a random asset that is able to attack or -1 if there are none
+func (f Faction) attacker(rng *dice.Rand) int {
+	var idx []int
+	for i, a := range f.Assets {
+		if a.Attack != "" {
+			idx = append(idx, i)
+		}
+	}
+
+	if len(idx) == 0 {
+		return -1
+	}
+
+	return idx[rng.Intn(len(idx))]
+}
+
+// attack has faction i attack a random rival with one of its assets
+func (r *FactionRoster) attack(rng *dice.Rand, i int) []string {
+	var targets []int
+	for j, f := range r.Factions {
+		if j != i && f.HP > 0 {
+			targets = append(targets, j)
+		}
+	}
+
+	var (
+		att                      = &r.Factions[i]
+		def                      = &r.Factions[targets[rng.Intn(len(targets))]]
+		aIdx                     = att.attacker(rng)
+		asset                    = &att.Assets[aIdx]
+		aAttr, dAttr, dmgDice, _ = asset.attackRolls()
+		aRoll                    = rng.Intn(10) + 1 + att.Rating(aAttr)
+		dRoll                    = rng.Intn(10) + 1 + def.Rating(dAttr)
+	)
+
+	// A faction with no assets left takes damage directly
+	if len(def.Assets) == 0 {
+		if aRoll < dRoll {
+			return []string{fmt.Sprintf("%s's %s attack %s and fail (%d vs %d)", att.Name, asset.Name, def.Name, aRoll, dRoll)}
+		}
+
+		dmg := rollDamage(rng, dmgDice)
+		def.HP -= dmg
+		att.advanceGoal(BloodTheEnemy, dmg)
+		return []string{fmt.Sprintf("%s's %s attack %s directly (%d vs %d) for %d damage", att.Name, asset.Name, def.Name, aRoll, dRoll, dmg)}
+	}
+
+	var (
+		dIdx   = rng.Intn(len(def.Assets))
+		target = &def.Assets[dIdx]
+		log    = []string{fmt.Sprintf("%s's %s attack %s's %s (%d vs %d)", att.Name, asset.Name, def.Name, target.Name, aRoll, dRoll)}
+	)
+
+	var aLost, dLost bool
+	if aRoll >= dRoll {
+		dmg := rollDamage(rng, dmgDice)
+		target.HP -= dmg
+		att.advanceGoal(BloodTheEnemy, dmg)
+		log = append(log, fmt.Sprintf("%s's %s take %d damage", def.Name, target.Name, dmg))
+
+		if target.HP <= 0 {
+			dLost = true
+			log = append(log, fmt.Sprintf("%s's %s are destroyed", def.Name, target.Name))
+
+			switch target.Attribute {
+			case Force:
+				att.advanceGoal(MilitaryConquest, 1)
+				if target.Rating > att.Force {
+					att.advanceGoal(InvincibleValor, 1)
+				}
+			case Cunning:
+				att.advanceGoal(IntelligenceCoup, 1)
+			}
+		}
+	}
+
+	if aRoll <= dRoll && target.Counter != "" {
+		dmg := rollDamage(rng, target.Counter)
+		asset.HP -= dmg
+		log = append(log, fmt.Sprintf("%s's %s counterattack for %d damage", def.Name, target.Name, dmg))
+
+		if asset.HP <= 0 {
+			aLost = true
+			log = append(log, fmt.Sprintf("%s's %s are destroyed", att.Name, asset.Name))
+		}
+	}
+
+	if aRoll < dRoll && target.Counter == "" {
+		log = append(log, "The attack fails")
+	}
+
+	// Remove destroyed assets last so that the asset pointers above stay valid
+	if dLost {
+		def.Assets = append(def.Assets[:dIdx], def.Assets[dIdx+1:]...)
+	}
+	if aLost {
+		att.Assets = append(att.Assets[:aIdx], att.Assets[aIdx+1:]...)
+	}
+
+	return log
+}
+
+// canBuy reports whether the faction can afford any asset
+func (f Faction) canBuy() bool {
+	for _, a := range factionAssets {
+		if a.Rating <= f.Rating(a.Attribute) && a.Cost <= f.FacCreds {
+			return true
+		}
+	}
+
+	return false
+}
+
+// buy purchases a random affordable asset, preferring Wealth assets when the goal calls for them
+func (f *Faction) buy(rng *dice.Rand) string {
+	attrs := []string{Force, Cunning, Wealth}
+	rng.Shuffle(len(attrs), func(i, j int) { attrs[i], attrs[j] = attrs[j], attrs[i] })
+	if f.Goal.Name == CommercialExpansion {
+		attrs = append([]string{Wealth}, attrs...)
+	}
+
+	for _, a := range attrs {
+		asset, ok := f.randomAsset(rng, a, f.FacCreds)
+		if !ok {
+			continue
+		}
+
+		f.FacCreds -= asset.Cost
+		f.Assets = append(f.Assets, asset)
+		f.advanceGoal(WealthOfWorlds, asset.Cost)
+		if a == Wealth {
+			f.advanceGoal(CommercialExpansion, 1)
+		}
+
+		return fmt.Sprintf("%s buys %s for %d FacCreds", f.Name, asset.Name, asset.Cost)
+	}
+
+	return fmt.Sprintf("%s finds nothing worth buying", f.Name)
+}
+
+// damaged reports whether the faction or any of its assets have lost HP
+func (f Faction) damaged() bool {
+	if f.HP < f.MaxHP {
+		return true
+	}
+
+	for _, a := range f.Assets {
+		if a.HP < a.MaxHP {
+			return true
+		}
+	}
+
+	return false
+}
+
+// repair heals the faction by the average of its highest and lowest ratings and spends 1 FacCred
+// per damaged asset to heal it by the rating of its attribute
+func (f *Faction) repair(rng *dice.Rand) string {
+	var (
+		hi, lo = f.Force, f.Force
+		healed []string
+	)
+
+	for _, n := range []int{f.Cunning, f.Wealth} {
+		if n > hi {
+			hi = n
+		}
+		if n < lo {
+			lo = n
+		}
+	}
+
+	if f.HP < f.MaxHP {
+		f.HP += (hi + lo + 1) / 2
+		if f.HP > f.MaxHP {
+			f.HP = f.MaxHP
+		}
+		healed = append(healed, fmt.Sprintf("itself to %d HP", f.HP))
+	}
+
+	for i := range f.Assets {
+		a := &f.Assets[i]
+		if a.HP >= a.MaxHP || f.FacCreds < 1 {
+			continue
+		}
+
+		f.FacCreds--
+		a.HP += f.Rating(a.Attribute)
+		if a.HP > a.MaxHP {
+			a.HP = a.MaxHP
+		}
+		healed = append(healed, fmt.Sprintf("%s to %d HP", a.Name, a.HP))
+	}
+
+	if len(healed) == 0 {
+		return fmt.Sprintf("%s has nothing it can repair", f.Name)
+	}
+
+	return fmt.Sprintf("%s repairs %s", f.Name, strings.Join(healed, ", "))
+}
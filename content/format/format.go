@@ -3,7 +3,9 @@ package format
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"html"
 	"strings"
 )
 
@@ -14,10 +16,12 @@ type OutputType string
 const (
 	TEXT     OutputType = "txt"
 	MARKDOWN OutputType = "md"
+	JSON     OutputType = "json"
+	HTML     OutputType = "html"
 )
 
 // Types of format output currently supported
-var Types = []OutputType{TEXT, MARKDOWN}
+var Types = []OutputType{TEXT, MARKDOWN, JSON, HTML}
 
 func (o OutputType) String() string {
 	return string(o)
@@ -35,7 +39,7 @@ func Find(name string) (OutputType, error) {
 }
 
 // Header formats and returns a header in format t, header sizes are defined in HTML/MARKDOWN terms with 1 being the largest
-// and reducing in size as the number increases.
+// and reducing in size as the number increases. JSON has no headers so nothing is returned.
 func Header(t OutputType, size int, text string) string {
 	out := ""
 
@@ -45,6 +49,9 @@ func Header(t OutputType, size int, text string) string {
 
 	case MARKDOWN:
 		out = fmt.Sprintf("%s %s\n\n", strings.Repeat("#", size), text)
+
+	case HTML:
+		out = fmt.Sprintf("<h%d>%s</h%d>\n", size, html.EscapeString(text), size)
 	}
 
 	return out
@@ -52,7 +59,8 @@ func Header(t OutputType, size int, text string) string {
 
 // Table returns a formatted Table of type t. Headers are optional so that different bits of content
 // can be concatenated into a single table through multiple calls to Table. Bear in mind that Markdown
-// tables must start with a header though.
+// tables must start with a header though. JSON tables are a list of objects keyed by the headers, or
+// a list of lists if there are no headers.
 func Table(t OutputType, headers []string, rows [][]string) string {
 	buf, sep, rowTmpl := new(bytes.Buffer), "", ""
 
@@ -60,6 +68,13 @@ func Table(t OutputType, headers []string, rows [][]string) string {
 		return "no table data found"
 	}
 
+	switch t {
+	case JSON:
+		return jsonTable(headers, rows)
+	case HTML:
+		return htmlTable(headers, rows)
+	}
+
 	switch t {
 	case TEXT:
 		sep, rowTmpl = "\t:\t", "%s\n"
@@ -82,3 +97,55 @@ func Table(t OutputType, headers []string, rows [][]string) string {
 
 	return buf.String()
 }
+
+// jsonTable returns rows as a JSON list of objects keyed by headers
+func jsonTable(headers []string, rows [][]string) string {
+	var v interface{} = rows
+
+	if len(headers) > 0 {
+		var objs []map[string]string
+		for _, row := range rows {
+			obj := make(map[string]string)
+			for i, cell := range row {
+				if i < len(headers) {
+					obj[headers[i]] = cell
+				}
+			}
+			objs = append(objs, obj)
+		}
+		v = objs
+	}
+
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err.Error()
+	}
+
+	return string(b) + "\n"
+}
+
+// htmlTable returns rows as an HTML table with headers in its head
+func htmlTable(headers []string, rows [][]string) string {
+	buf := new(bytes.Buffer)
+
+	fmt.Fprintln(buf, "<table>")
+	if len(headers) > 0 {
+		fmt.Fprint(buf, "<thead><tr>")
+		for _, h := range headers {
+			fmt.Fprintf(buf, "<th>%s</th>", html.EscapeString(h))
+		}
+		fmt.Fprintln(buf, "</tr></thead>")
+	}
+
+	fmt.Fprintln(buf, "<tbody>")
+	for _, row := range rows {
+		fmt.Fprint(buf, "<tr>")
+		for _, cell := range row {
+			fmt.Fprintf(buf, "<td>%s</td>", html.EscapeString(cell))
+		}
+		fmt.Fprintln(buf, "</tr>")
+	}
+	fmt.Fprintln(buf, "</tbody>\n</table>")
+
+	return buf.String()
+}
@@ -12,14 +12,10 @@ to attempt to cater to them.
 
 import (
 	"fmt"
-	"math/rand"
 	"strings"
-	"time"
-)
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
+	"github.com/nboughton/swnt/dice"
+)
 
 // Gender is a shorthand type for IDing general labels
 type Gender string
@@ -36,15 +32,16 @@ const (
 var Genders = []Gender{Male, Female, Other}
 
 // Random returns a random Gender
-func Random() Gender {
-	n := rand.Intn(len(Genders))
+func Random(rng *dice.Rand) Gender {
+	n := rng.Intn(len(Genders))
 	return Genders[n]
 }
 
-// Find returns the id constant or an error if it doesn't exist
-func Find(name string) (Gender, error) {
+// Find returns the id constant or an error if it doesn't exist. Any (or no name) returns a random
+// Gender drawn from rng.
+func Find(rng *dice.Rand, name string) (Gender, error) {
 	if strings.ToLower(name) == strings.ToLower(Any.String()) || name == "" {
-		return Random(), nil
+		return Random(rng), nil
 	}
 
 	for _, g := range Genders {
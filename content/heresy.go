@@ -6,6 +6,7 @@ import (
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Heresy with a Body
@@ -17,12 +18,12 @@ type Heresy struct {
 }
 
 // NewHeresy with random characteristics
-func NewHeresy() Heresy {
+func NewHeresy(rng *dice.Rand) Heresy {
 	h := Heresy{
-		Founder:     heresyTable.founder.Roll(),
-		MajorHeresy: heresyTable.majorHeresy.Roll(),
-		Attitude:    heresyTable.attitude.Roll(),
-		Quirk:       heresyTable.quirk.Roll(),
+		Founder:     rng.Roll(heresyTable.founder),
+		MajorHeresy: rng.Roll(heresyTable.majorHeresy),
+		Attitude:    rng.Roll(heresyTable.attitude),
+		Quirk:       rng.Roll(heresyTable.quirk),
 	}
 	return h
 }
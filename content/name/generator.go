@@ -1,10 +1,8 @@
 package name
 
 import (
-	"math/rand"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/dice"
@@ -12,28 +10,24 @@ import (
 
 var badPrefix = regexp.MustCompile(`[cflmnr][^aeiouyh]`)
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // Name generator. This is a primitive first effort that will be refined over time
 
-// Generate creates a random name by combining alternating vowels and consonants
-func Generate(ln int) string {
+// Generate creates a random name by combining alternating vowels and consonants drawn from rng
+func Generate(rng *dice.Rand, ln int) string {
 	name := ""
-	for i := rand.Intn(2); len(name) <= ln; i++ {
+	for i := rng.Intn(2); len(name) <= ln; i++ {
 		if i%2 != 0 {
-			c := con.Roll()
+			c := rng.Table(con)
 
 			if name == "" {
 				for badPrefix.MatchString(c) { // I don't like starting a name with these
-					c = con.Roll()
+					c = rng.Table(con)
 				}
 			}
 
 			name += c
 		} else {
-			name += vl.Roll()
+			name += rng.Table(vl)
 		}
 	}
 
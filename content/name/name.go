@@ -4,6 +4,7 @@ package name
 import (
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/culture"
+	"github.com/nboughton/swnt/dice"
 )
 
 // table represents the collection of roll lists keyed by cultural background
@@ -18,10 +19,11 @@ type table struct {
 // Tables represents a set of table structs
 type tables []table
 
-// ByCulture returns a name table that matches the given culture
-func (t tables) ByCulture(c culture.Culture) table {
+// ByCulture returns a name table that matches the given culture. The table for Any is chosen at
+// random with rng.
+func (t tables) ByCulture(rng *dice.Rand, c culture.Culture) table {
 	if c == culture.Any {
-		c = culture.Random()
+		c = culture.Random(rng)
 	}
 
 	for _, tbl := range t {
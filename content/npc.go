@@ -3,7 +3,6 @@ package content
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/culture"
@@ -11,10 +10,13 @@ import (
 	"github.com/nboughton/swnt/content/gender"
 	"github.com/nboughton/swnt/content/name"
 	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 )
 
 func init() {
 	table.Registry.Add(npcTable.D10.(roll.Table))
+
+	dice.AddAction("npc.BiggestProblem", "A loved one is in trouble", actionLovedOne)
 }
 
 // Patron is a Patron
@@ -23,8 +25,8 @@ type Patron struct {
 }
 
 // NewPatron just roll Patron details
-func NewPatron() Patron {
-	return Patron{Fields: patronTable.Roll()}
+func NewPatron(rng *dice.Rand) Patron {
+	return Patron{Fields: patronTable.Roll(rng)}
 }
 
 // Format patron data as type t
@@ -54,38 +56,38 @@ type NPCHooks struct {
 }
 
 // NewNPC roll a new NPC
-func NewNPC(ctr culture.Culture, g gender.Gender, isPatron bool) NPC {
+func NewNPC(rng *dice.Rand, ctr culture.Culture, g gender.Gender, isPatron bool) NPC {
 	n := NPC{
 		Gender:  g,
 		Culture: ctr,
-		Fields:  npcTable.Roll(),
+		Fields:  npcTable.Roll(rng),
 		Hooks: NPCHooks{
-			Manner:     npcHooksTable.manner.Roll(),
-			Outcome:    npcHooksTable.outcome.Roll(),
-			Motivation: npcHooksTable.motivation.Roll(),
-			Want:       npcHooksTable.want.Roll(),
-			Power:      npcHooksTable.power.Roll(),
-			Hook:       npcHooksTable.hook.Roll(),
+			Manner:     rng.Roll(npcHooksTable.manner),
+			Outcome:    rng.Roll(npcHooksTable.outcome),
+			Motivation: rng.Roll(npcHooksTable.motivation),
+			Want:       rng.Roll(npcHooksTable.want),
+			Power:      rng.Roll(npcHooksTable.power),
+			Hook:       rng.Roll(npcHooksTable.hook),
 		},
-		Reaction: Reaction.Roll(),
+		Reaction: rng.Roll(Reaction),
 	}
 
 	if isPatron {
-		n.Patron = NewPatron()
+		n.Patron = NewPatron(rng)
 	}
 
-	nm := name.Table.ByCulture(ctr)
+	nm := name.Table.ByCulture(rng, ctr)
 	switch g {
 	case gender.Male:
-		n.Name = fmt.Sprintf("%s %s", nm.Male.Roll(), nm.Surname.Roll())
+		n.Name = fmt.Sprintf("%s %s", rng.Roll(nm.Male), rng.Roll(nm.Surname))
 	case gender.Female:
-		n.Name = fmt.Sprintf("%s %s", nm.Female.Roll(), nm.Surname.Roll())
+		n.Name = fmt.Sprintf("%s %s", rng.Roll(nm.Female), rng.Roll(nm.Surname))
 	case gender.Other, gender.Any:
-		switch rand.Intn(2) {
+		switch rng.Intn(2) {
 		case 0:
-			n.Name = fmt.Sprintf("%s %s", nm.Male.Roll(), nm.Surname.Roll())
+			n.Name = fmt.Sprintf("%s %s", rng.Roll(nm.Male), rng.Roll(nm.Surname))
 		case 1:
-			n.Name = fmt.Sprintf("%s %s", nm.Female.Roll(), nm.Surname.Roll())
+			n.Name = fmt.Sprintf("%s %s", rng.Roll(nm.Female), rng.Roll(nm.Surname))
 		}
 	}
 
@@ -448,15 +450,7 @@ var npcTable = table.OneRoll{
 		Dice: roll.Dice{N: 1, Die: roll.D10},
 		Items: []roll.TableItem{
 			{Match: []int{1}, Text: "They have significant debt or money woes"},
-			{Match: []int{2}, Text: "A loved one is in trouble", Action: func() string {
-				tbl, _ := table.Registry.Get("npc.BiggestProblem")
-
-				var res string
-				for res = tbl.Roll(); res != tbl.Items[1].Text; res = tbl.Roll() {
-					return res
-				}
-				return ""
-			}},
+			{Match: []int{2}, Text: "A loved one is in trouble"},
 			{Match: []int{3}, Text: "Romantic failure with a desired person"},
 			{Match: []int{4}, Text: "Drug or behavioral addiction"},
 			{Match: []int{5}, Text: "Their superior dislikes or resents them"},
@@ -510,3 +504,14 @@ var npcTable = table.OneRoll{
 		},
 	},
 }
+
+// actionLovedOne rolls the trouble a loved one of an NPC is in
+func actionLovedOne(rng *dice.Rand) string {
+	tbl, _ := table.Registry.Get("npc.BiggestProblem")
+
+	var res string
+	for res = rng.Roll(tbl); res != tbl.Items[1].Text; res = rng.Roll(tbl) {
+		return res
+	}
+	return ""
+}
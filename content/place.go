@@ -7,6 +7,7 @@ import (
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Place represents the aggregate details of a generated place
@@ -17,18 +18,18 @@ type Place struct {
 }
 
 // NewPlace roll a new place, default to urban
-func NewPlace(wilderness bool) Place {
+func NewPlace(rng *dice.Rand, wilderness bool) Place {
 	og := ""
 	if wilderness {
-		og = placeTable.ongoingsWild.Roll()
+		og = rng.Roll(placeTable.ongoingsWild)
 	} else {
-		og = placeTable.ongoingsCiv.Roll()
+		og = rng.Roll(placeTable.ongoingsCiv)
 	}
 
 	return Place{
-		Reward:   placeTable.reward.Roll(),
+		Reward:   rng.Roll(placeTable.reward),
 		Ongoings: og,
-		Hazard:   placeTable.hazard.Roll(),
+		Hazard:   placeTable.hazard.Roll(rng),
 	}
 }
 
@@ -3,11 +3,11 @@ package content
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 )
 
 // POI Point of Interest
@@ -18,13 +18,13 @@ type POI struct {
 }
 
 // NewPOI roll a new point of interest
-func NewPOI() POI {
-	t := poiTable.Tables[rand.Intn(len(poiTable.Tables))]
+func NewPOI(rng *dice.Rand) POI {
+	t := poiTable.Tables[rng.Intn(len(poiTable.Tables))]
 
 	return POI{
 		Point:     t.Name,
-		Occupied:  t.SubTable1.Roll(),
-		Situation: t.SubTable2.Roll(),
+		Occupied:  rng.Roll(t.SubTable1),
+		Situation: rng.Roll(t.SubTable2),
 	}
 }
 
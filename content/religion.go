@@ -3,7 +3,6 @@ package content
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/format"
@@ -13,6 +12,8 @@ import (
 
 func init() {
 	table.Registry.Add(religionTable.leadership)
+
+	dice.AddAction("religion.Leadership", "No universal leadership", actionRegionalLeadership)
 }
 
 // Religion is pretty self explanatory
@@ -23,11 +24,11 @@ type Religion struct {
 }
 
 // NewReligion with random characteristics
-func NewReligion() Religion {
+func NewReligion(rng *dice.Rand) Religion {
 	r := Religion{
-		Evolution:       religionTable.evolution.Roll(),
-		Leadership:      religionTable.leadership.Roll(),
-		OriginTradition: religionTable.origin.Roll(),
+		Evolution:       rng.Roll(religionTable.evolution),
+		Leadership:      rng.Roll(religionTable.leadership),
+		OriginTradition: rng.Roll(religionTable.origin),
 	}
 	return r
 }
@@ -99,16 +100,20 @@ var religionTable = struct {
 			{Match: []int{1, 2}, Text: "Patriarch/Matriarch. A single leader determines doctrine for the entire religion, possibly in consultation with other clerics."},
 			{Match: []int{3, 4}, Text: "Council. A group of the oldest and most revered clergy determine the course of the faith."},
 			{Match: []int{5}, Text: "Democracy. Every member has an equal voice in matters of faith, with doctrine usually decided at regular church- wide councils."},
-			{Match: []int{6}, Text: "No universal leadership", Action: func() string {
-				tbl, _ := table.Registry.Get("religion.Leadership")
-				tbl.Dice = roll.Dice{N: 1, Die: dice.D5}
-
-				if rand.Intn(6)+1 == 6 {
-					return ""
-				}
-
-				return "Each region governed independently by a " + tbl.Roll()
-			}},
+			{Match: []int{6}, Text: "No universal leadership"},
 		},
 	},
 }
+
+// actionRegionalLeadership rolls the leadership of each region of a Religion with no universal
+// leadership
+func actionRegionalLeadership(rng *dice.Rand) string {
+	tbl, _ := table.Registry.Get("religion.Leadership")
+	tbl.Dice = roll.Dice{N: 1, Die: dice.D5}
+
+	if rng.Intn(6)+1 == 6 {
+		return ""
+	}
+
+	return "Each region governed independently by a " + rng.Roll(tbl)
+}
@@ -0,0 +1,174 @@
+package sector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/dice"
+)
+
+// AddStar generates a new Star at row, col. If name is empty a unique name is generated, otherwise
+// it must not already be in use. The remaining arguments are the same as for NewSector.
+func (s *Stars) AddStar(rng *dice.Rand, row, col int, name string, excludeTags []string, fullTags bool, poiChance, otherWorldChance int) (*Star, error) {
+	if err := s.vacant(row, col); err != nil {
+		return nil, err
+	}
+
+	if name == "" {
+		name = s.systemName(rng)
+	} else if strings.TrimSpace(name) == "" {
+		return nil, fmt.Errorf("systems must have a name")
+	} else if s.NameUsed(name, nil) {
+		return nil, fmt.Errorf("there is already a system called \"%s\"", name)
+	}
+
+	star, err := NewStar(rng, row, col, name, excludeTags, fullTags, poiChance, otherWorldChance)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Systems = append(s.Systems, star)
+
+	return star, nil
+}
+
+// RemoveStar removes the Star named n along with any lanes to it. Factions based there lose their
+// homeworld, the names of those factions are returned.
+func (s *Stars) RemoveStar(n string) ([]string, error) {
+	star, err := s.Find(n)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, sys := range s.Systems {
+		if sys == star {
+			s.Systems = append(s.Systems[:i], s.Systems[i+1:]...)
+			break
+		}
+	}
+
+	var lanes Lanes
+	for _, l := range s.Lanes {
+		if l.From != star.Name && l.To != star.Name {
+			lanes = append(lanes, l)
+		}
+	}
+	s.Lanes = lanes
+
+	var homeless []string
+	for i := range s.Factions {
+		if s.Factions[i].Homeworld == star.Name {
+			s.Factions[i].Homeworld = ""
+			homeless = append(homeless, s.Factions[i].Name)
+		}
+	}
+
+	return homeless, nil
+}
+
+// MoveStar moves the Star named n to the empty hex at row, col. Lanes to it are kept.
+func (s *Stars) MoveStar(n string, row, col int) error {
+	star, err := s.Find(n)
+	if err != nil {
+		return err
+	}
+
+	if err := s.vacant(row, col); err != nil {
+		return err
+	}
+
+	star.Row, star.Col = row, col
+
+	return nil
+}
+
+// RenameStar renames the Star named n to name, which must not already be in use, and updates the
+// lanes and factions that refer to it.
+func (s *Stars) RenameStar(n, name string) error {
+	star, err := s.Find(n)
+	if err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("systems must have a name")
+	}
+
+	if s.NameUsed(name, star) {
+		return fmt.Errorf("there is already a system called \"%s\"", name)
+	}
+
+	for i := range s.Lanes {
+		if s.Lanes[i].From == star.Name {
+			s.Lanes[i].From = name
+		}
+
+		if s.Lanes[i].To == star.Name {
+			s.Lanes[i].To = name
+		}
+	}
+
+	for i := range s.Factions {
+		if s.Factions[i].Homeworld == star.Name {
+			s.Factions[i].Homeworld = name
+		}
+	}
+
+	star.Name = name
+
+	return nil
+}
+
+// RerollWorld generates a new world in place of the i'th world (counting from 0, the primary
+// world) of the Star named n. The world keeps its culture and whether it is the primary world.
+func (s *Stars) RerollWorld(rng *dice.Rand, n string, i int, excludeTags []string, fullTags bool) (content.World, error) {
+	star, err := s.Find(n)
+	if err != nil {
+		return content.World{}, err
+	}
+
+	if i < 0 || i >= len(star.Worlds) {
+		return content.World{}, fmt.Errorf("%s has %d worlds, use 0 to %d", star.Name, len(star.Worlds), len(star.Worlds)-1)
+	}
+
+	w, err := content.NewWorld(rng, star.Worlds[i].Primary, star.Worlds[i].Culture, fullTags, excludeTags)
+	if err != nil {
+		return content.World{}, err
+	}
+
+	star.Worlds[i] = w
+
+	return w, nil
+}
+
+// RerollPOI generates a new point of interest in place of the i'th (counting from 0) of the Star
+// named n
+func (s *Stars) RerollPOI(rng *dice.Rand, n string, i int) (content.POI, error) {
+	star, err := s.Find(n)
+	if err != nil {
+		return content.POI{}, err
+	}
+
+	if i < 0 || i >= len(star.POIs) {
+		return content.POI{}, fmt.Errorf("%s has %d points of interest", star.Name, len(star.POIs))
+	}
+
+	star.POIs[i] = content.NewPOI(rng)
+
+	return star.POIs[i], nil
+}
+
+// vacant returns an error if row, col is outside the sector or already has a Star
+func (s *Stars) vacant(row, col int) error {
+	h := Hex{row, col}
+	if !s.Contains(h) {
+		return fmt.Errorf("hex %s is outside the sector (%d rows by %d cols)", h, s.Rows, s.Cols)
+	}
+
+	if s.active(row, col) {
+		return fmt.Errorf("there is already a system at %s", h)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package sector
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/dice"
+)
+
+// Faction homeworlds are weighted by the population and tech level of the system's primary world
+var (
+	popWeight = map[string]int{
+		"failedColony":       0,
+		"outpost":            1,
+		"fewerThanMillion":   2,
+		"severalMillion":     3,
+		"hundredsOfMillions": 5,
+		"billions":           8,
+		"alien":              3,
+	}
+
+	tlWeight = map[string]int{
+		"TL0":  1,
+		"TL1":  1,
+		"TL2":  2,
+		"TL3":  3,
+		"TL4":  4,
+		"TL4+": 5,
+		"TL5":  6,
+	}
+)
+
+// majorFactionWeight is the homeworld weight at which a faction is generated as a Major faction
+const majorFactionWeight = 20
+
+// homeworldWeight returns the likelihood of a system being chosen as a faction homeworld
+func homeworldWeight(star *Star) int {
+	w := star.Worlds[0]
+	return popWeight[w.Population.Code] * tlWeight[w.TechLevel.Code]
+}
+
+// GenerateFactions creates 3 to 6 factions for the sector with homeworlds chosen among its systems.
+// Populous and advanced systems are more likely to be homeworlds and produce larger factions, a
+// Regional Hegemon world produces a Hegemon.
+func (s *Stars) GenerateFactions(rng *dice.Rand) {
+	var (
+		candidates []*Star
+		total      int
+	)
+
+	for _, star := range s.Systems {
+		if w := homeworldWeight(star); w > 0 {
+			candidates = append(candidates, star)
+			total += w
+		}
+	}
+
+	for n := rng.Intn(4) + 3; n > 0 && len(candidates) > 0; n-- {
+		// Weighted selection without replacement
+		r, i := rng.Intn(total), 0
+		for ; r >= homeworldWeight(candidates[i]); i++ {
+			r -= homeworldWeight(candidates[i])
+		}
+
+		star := candidates[i]
+		candidates = append(candidates[:i], candidates[i+1:]...)
+		total -= homeworldWeight(star)
+
+		size := content.MinorFaction
+		if homeworldWeight(star) >= majorFactionWeight {
+			size = content.MajorFaction
+		}
+
+		for _, t := range star.Worlds[0].Tags {
+			if t.Name == "Regional Hegemon" {
+				size = content.HegemonFaction
+			}
+		}
+
+		f := content.NewFaction(rng, size, star.Name)
+		for s.factionNameUsed(f.Name) {
+			f = content.NewFaction(rng, size, star.Name)
+		}
+
+		s.Factions = append(s.Factions, f)
+	}
+}
+
+func (s *Stars) factionNameUsed(n string) bool {
+	for _, f := range s.Factions {
+		if f.Name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FactionMarker returns the marker used on GM maps for a faction based at star, or an empty string
+// if no faction is based there
+func (s *Stars) FactionMarker(star *Star) string {
+	for i, f := range s.Factions {
+		if f.Homeworld == star.Name {
+			return "F" + strconv.Itoa(i+1)
+		}
+	}
+
+	return ""
+}
+
+// FormatFactions returns a summary table of the sector's factions, including their map markers,
+// formatted as type t
+func (s *Stars) FormatFactions(t format.OutputType) string {
+	var rows [][]string
+	for i, f := range s.Factions {
+		rows = append(rows, []string{
+			"F" + strconv.Itoa(i+1),
+			f.Name,
+			string(f.Size),
+			f.Homeworld,
+			fmt.Sprintf("%d/%d/%d", f.Force, f.Cunning, f.Wealth),
+			f.Goal.Name,
+		})
+	}
+
+	return format.Table(t, []string{"Marker", "Faction", "Size", "Homeworld", "F/C/W", "Goal"}, rows)
+}
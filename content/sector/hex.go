@@ -0,0 +1,104 @@
+package sector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Hex geometry for the sector map. Hexes are flat topped and odd columns are shifted down by half
+// a hex, which is the layout drawn by haxscii.
+
+// Hex is a position on the sector map
+type Hex struct {
+	Row, Col int
+}
+
+// cube converts h to cube coordinates, which makes distances trivial to calculate
+func (h Hex) cube() (int, int, int) {
+	x := h.Col
+	z := h.Row - (h.Col-(h.Col&1))/2
+	return x, -x - z, z
+}
+
+// Distance returns the number of hexes between h and o
+func (h Hex) Distance(o Hex) int {
+	x1, y1, z1 := h.cube()
+	x2, y2, z2 := o.cube()
+
+	return max(abs(x1-x2), abs(y1-y2), abs(z1-z2))
+}
+
+// Neighbours returns the 6 hexes adjacent to h. Some of these may lie outside of the sector map.
+func (h Hex) Neighbours() []Hex {
+	// Odd columns sit lower than even columns so their diagonal neighbours are a row further down
+	d := 0
+	if h.Col%2 != 0 {
+		d = 1
+	}
+
+	return []Hex{
+		{h.Row - 1, h.Col},
+		{h.Row + 1, h.Col},
+		{h.Row - 1 + d, h.Col - 1},
+		{h.Row + d, h.Col - 1},
+		{h.Row - 1 + d, h.Col + 1},
+		{h.Row + d, h.Col + 1},
+	}
+}
+
+func (h Hex) String() string {
+	return fmt.Sprintf("%d,%d", h.Row, h.Col)
+}
+
+// Hex returns the position of Star s
+func (s *Star) Hex() Hex {
+	return Hex{s.Row, s.Col}
+}
+
+// Contains reports whether h lies within the bounds of the sector
+func (s *Stars) Contains(h Hex) bool {
+	return h.Row >= 0 && h.Row < s.Rows && h.Col >= 0 && h.Col < s.Cols
+}
+
+// Neighbours returns the hexes adjacent to h that lie within the sector
+func (s *Stars) Neighbours(h Hex) []Hex {
+	var out []Hex
+
+	for _, n := range h.Neighbours() {
+		if s.Contains(n) {
+			out = append(out, n)
+		}
+	}
+
+	return out
+}
+
+// Find returns the Star named n. The search is case insensitive for convenience
+func (s *Stars) Find(n string) (*Star, error) {
+	for _, star := range s.Systems {
+		if strings.ToLower(star.Name) == strings.ToLower(n) {
+			return star, nil
+		}
+	}
+
+	return nil, fmt.Errorf("no system with name \"%s\"", n)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+
+	return n
+}
+
+func max(n ...int) int {
+	m := n[0]
+	for _, v := range n[1:] {
+		if v > m {
+			m = v
+		}
+	}
+
+	return m
+}
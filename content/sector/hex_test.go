@@ -0,0 +1,82 @@
+package sector
+
+import "testing"
+
+func TestHexDistance(t *testing.T) {
+	for _, tc := range []struct {
+		a, b Hex
+		want int
+	}{
+		{Hex{0, 0}, Hex{0, 0}, 0},
+		{Hex{0, 0}, Hex{1, 0}, 1},
+		{Hex{0, 0}, Hex{0, 1}, 1}, // Odd columns sit half a hex lower
+		{Hex{1, 0}, Hex{0, 1}, 1},
+		{Hex{0, 0}, Hex{1, 1}, 2},
+		{Hex{0, 1}, Hex{1, 2}, 1},
+		{Hex{1, 1}, Hex{0, 2}, 2},
+		{Hex{0, 0}, Hex{0, 2}, 2},
+		{Hex{0, 0}, Hex{9, 7}, 13},
+		{Hex{4, 3}, Hex{2, 6}, 4},
+	} {
+		if got := tc.a.Distance(tc.b); got != tc.want {
+			t.Errorf("%s to %s = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+
+		if got := tc.b.Distance(tc.a); got != tc.want {
+			t.Errorf("%s to %s = %d, want %d", tc.b, tc.a, got, tc.want)
+		}
+	}
+}
+
+func TestHexNeighbours(t *testing.T) {
+	for _, tc := range []struct {
+		h    Hex
+		want []Hex
+	}{
+		{Hex{2, 2}, []Hex{{1, 2}, {3, 2}, {1, 1}, {2, 1}, {1, 3}, {2, 3}}},
+		{Hex{2, 3}, []Hex{{1, 3}, {3, 3}, {2, 2}, {3, 2}, {2, 4}, {3, 4}}},
+	} {
+		got := tc.h.Neighbours()
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s has neighbours %v, want %v", tc.h, got, tc.want)
+		}
+
+		for i, n := range got {
+			if n != tc.want[i] {
+				t.Errorf("%s has neighbours %v, want %v", tc.h, got, tc.want)
+				break
+			}
+
+			if d := tc.h.Distance(n); d != 1 {
+				t.Errorf("%s is %d hexes from its neighbour %s", tc.h, d, n)
+			}
+		}
+	}
+}
+
+func TestStarsNeighbours(t *testing.T) {
+	s := &Stars{Rows: 10, Cols: 8}
+
+	for _, tc := range []struct {
+		h    Hex
+		want int
+	}{
+		{Hex{0, 0}, 2},
+		{Hex{0, 1}, 5},
+		{Hex{9, 7}, 2},
+		{Hex{9, 6}, 5},
+		{Hex{0, 7}, 3},
+		{Hex{4, 4}, 6},
+	} {
+		got := s.Neighbours(tc.h)
+		if len(got) != tc.want {
+			t.Errorf("%s has neighbours %v in the sector, want %d", tc.h, got, tc.want)
+		}
+
+		for _, n := range got {
+			if !s.Contains(n) {
+				t.Errorf("%s has neighbour %s outside of the sector", tc.h, n)
+			}
+		}
+	}
+}
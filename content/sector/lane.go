@@ -0,0 +1,95 @@
+package sector
+
+import (
+	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/dice"
+)
+
+// maxLaneLength is the furthest, in hexes, that an established lane will reach between systems
+const maxLaneLength = 3
+
+// Lane is an established trade or travel route between two systems
+type Lane struct {
+	From, To string
+	Label    string
+}
+
+// Lanes is a collection of Lane
+type Lanes []Lane
+
+// Format returns the lanes as a table of type t
+func (l Lanes) Format(t format.OutputType) string {
+	var rows [][]string
+	for _, lane := range l {
+		rows = append(rows, []string{lane.From, lane.To, lane.Label})
+	}
+
+	return format.Table(t, []string{"From", "To", "Lane"}, rows)
+}
+
+// GenerateLanes links the spacefaring (TL4 and above) systems of the sector with established lanes.
+// Lanes follow the shortest links that join those systems together, up to maxLaneLength hexes long,
+// so they trace out the major spike routes of the sector.
+func (s *Stars) GenerateLanes(rng *dice.Rand) {
+	var ports []*Star
+	for _, star := range s.Systems {
+		switch star.Worlds[0].TechLevel.Code {
+		case "TL4", "TL4+", "TL5":
+			ports = append(ports, star)
+		}
+	}
+
+	// Prim's algorithm, restarted from each unconnected port so that isolated clusters get lanes too
+	linked := make(map[*Star]bool)
+	for _, root := range ports {
+		if linked[root] {
+			continue
+		}
+
+		linked[root] = true
+		for {
+			var (
+				from, to *Star
+				best     = maxLaneLength + 1
+			)
+
+			for _, a := range ports {
+				if !linked[a] {
+					continue
+				}
+
+				for _, b := range ports {
+					if linked[b] {
+						continue
+					}
+
+					if d := a.Hex().Distance(b.Hex()); d < best {
+						from, to, best = a, b, d
+					}
+				}
+			}
+
+			if to == nil {
+				break
+			}
+
+			linked[to] = true
+			s.Lanes = append(s.Lanes, Lane{From: from.Name, To: to.Name, Label: rng.Roll(laneTable)})
+		}
+	}
+}
+
+var laneTable = roll.List{
+	Name: "Lane",
+	Items: []string{
+		"Trade lane",
+		"Spike route",
+		"Courier run",
+		"Military corridor",
+		"Pilgrim route",
+		"Ore haul",
+		"Smugglers' run",
+		"Colonial lifeline",
+	},
+}
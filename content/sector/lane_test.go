@@ -0,0 +1,84 @@
+package sector
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/dice"
+)
+
+// testStar returns a Star with a single world of tech level tl
+func testStar(name string, row, col int, tl string) *Star {
+	return &Star{
+		Name:   name,
+		Row:    row,
+		Col:    col,
+		Worlds: []content.World{{Name: name, TechLevel: content.ParseTechLevel(tl)}},
+	}
+}
+
+func TestGenerateLanes(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		systems []*Star
+		want    []string // From-To pairs, sorted
+	}{
+		{
+			"chain",
+			[]*Star{testStar("A", 0, 0, "TL4"), testStar("B", 0, 2, "TL4+"), testStar("C", 0, 4, "TL5")},
+			[]string{"A-B", "B-C"},
+		},
+		{
+			"shortest links",
+			[]*Star{testStar("A", 0, 0, "TL4"), testStar("B", 2, 2, "TL4"), testStar("C", 1, 0, "TL4")},
+			[]string{"A-C", "C-B"},
+		},
+		{
+			"too far apart",
+			[]*Star{testStar("A", 0, 0, "TL4"), testStar("B", 0, 4, "TL4")},
+			nil,
+		},
+		{
+			"separate clusters",
+			[]*Star{testStar("A", 0, 0, "TL4"), testStar("B", 1, 1, "TL4"), testStar("C", 8, 7, "TL4"), testStar("D", 9, 7, "TL4")},
+			[]string{"A-B", "C-D"},
+		},
+		{
+			"not spacefaring",
+			[]*Star{testStar("A", 0, 0, "TL4"), testStar("B", 0, 1, "TL3"), testStar("C", 0, 2, "TL4")},
+			[]string{"A-C"},
+		},
+	} {
+		s := &Stars{Rows: 10, Cols: 8, Systems: tc.systems}
+		s.GenerateLanes(dice.New(1))
+
+		var got []string
+		for _, l := range s.Lanes {
+			from, _ := s.Find(l.From)
+			to, _ := s.Find(l.To)
+			if d := from.Hex().Distance(to.Hex()); d > maxLaneLength {
+				t.Errorf("%s: lane %s-%s is %d hexes long", tc.name, l.From, l.To, d)
+			}
+
+			if l.Label == "" {
+				t.Errorf("%s: lane %s-%s has no label", tc.name, l.From, l.To)
+			}
+
+			got = append(got, l.From+"-"+l.To)
+		}
+		sort.Strings(got)
+
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: lanes are %v, want %v", tc.name, got, tc.want)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s: lanes are %v, want %v", tc.name, got, tc.want)
+				break
+			}
+		}
+	}
+}
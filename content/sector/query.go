@@ -0,0 +1,150 @@
+package sector
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/culture"
+)
+
+// TechLevels lists world Tech Level codes in ascending order
+var TechLevels = []string{"TL0", "TL1", "TL2", "TL3", "TL4", "TL4+", "TL5"}
+
+// Query describes the worlds to find in a sector. Empty fields match any world and a world must
+// match every field that is set. Atmosphere, Biosphere, Population and POI match any value that
+// contains them, ignoring case, so "breathable" finds worlds with a "Breathable mix". Attributes
+// also match their code, i.e "humanMiscible".
+type Query struct {
+	Tags       []string // Worlds must have every tag listed
+	TechLevel  string   // Exact Tech Level code, i.e "TL4+"
+	MinTL      string   // Lowest Tech Level code, i.e "TL4" finds TL4, TL4+ and TL5 worlds
+	Atmosphere string
+	Biosphere  string
+	Population string
+	Culture    string
+	POI        string // The world's system must have a point of interest of this type
+}
+
+// Result is a World found by a Query, along with its Star
+type Result struct {
+	Star  *Star
+	World content.World
+}
+
+// Query returns the worlds of the sector that match q, in the order of its Stars
+func (s *Stars) Query(q Query) ([]Result, error) {
+	if err := q.validate(); err != nil {
+		return nil, err
+	}
+
+	var res []Result
+	for _, star := range s.Systems {
+		if q.POI != "" && !star.hasPOI(q.POI) {
+			continue
+		}
+
+		for _, w := range star.Worlds {
+			if q.match(w) {
+				res = append(res, Result{Star: star, World: w})
+			}
+		}
+	}
+
+	return res, nil
+}
+
+// validate checks that the Tech Levels and culture of q are known
+func (q Query) validate() error {
+	for _, tl := range []string{q.TechLevel, q.MinTL} {
+		if tl != "" && tlIndex(tl) < 0 {
+			return fmt.Errorf("unknown tech level \"%s\", tech levels are %s", tl, strings.Join(TechLevels, ", "))
+		}
+	}
+
+	if q.Culture != "" && !knownCulture(q.Culture) {
+		return fmt.Errorf("no culture found for \"%s\", options available are %s", q.Culture, culture.Cultures)
+	}
+
+	return nil
+}
+
+// match reports whether w matches q, apart from its POI
+func (q Query) match(w content.World) bool {
+	for _, t := range q.Tags {
+		if !hasTag(w, t) {
+			return false
+		}
+	}
+
+	tl := tlIndex(w.TechLevel.Code)
+	if q.TechLevel != "" && tl != tlIndex(q.TechLevel) {
+		return false
+	}
+
+	if q.MinTL != "" && tl < tlIndex(q.MinTL) {
+		return false
+	}
+
+	if q.Culture != "" && !strings.EqualFold(w.Culture.String(), q.Culture) {
+		return false
+	}
+
+	return matchAttribute(w.Atmosphere.Attribute, q.Atmosphere) &&
+		matchAttribute(w.Biosphere.Attribute, q.Biosphere) &&
+		matchAttribute(w.Population.Attribute, q.Population)
+}
+
+// matchAttribute reports whether a has the code s or its text contains s, ignoring case
+func matchAttribute(a content.Attribute, s string) bool {
+	return strings.EqualFold(a.Code, s) || contains(a.Desc, s)
+}
+
+// hasPOI reports whether s has a point of interest whose type contains p
+func (s *Star) hasPOI(p string) bool {
+	for _, poi := range s.POIs {
+		if contains(poi.Point, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasTag reports whether w has the tag called t, ignoring case
+func hasTag(w content.World, t string) bool {
+	for _, tag := range w.Tags {
+		if strings.EqualFold(tag.Name, t) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// tlIndex returns the position of the Tech Level code tl in TechLevels, or -1
+func tlIndex(tl string) int {
+	for i, c := range TechLevels {
+		if strings.EqualFold(c, tl) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// knownCulture reports whether c is one of culture.Cultures, ignoring case
+func knownCulture(c string) bool {
+	for _, ctr := range culture.Cultures {
+		if strings.EqualFold(ctr.String(), c) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// contains reports whether s contains substr, ignoring case
+func contains(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
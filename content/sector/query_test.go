@@ -0,0 +1,112 @@
+package sector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/culture"
+)
+
+// queryWorld returns a World with the given attributes and tags
+func queryWorld(name, atmosphere, biosphere, population, tl string, ctr culture.Culture, tags ...string) content.World {
+	w := content.World{
+		Name:       name,
+		Culture:    ctr,
+		Atmosphere: content.ParseAtmosphere(atmosphere),
+		Biosphere:  content.ParseBiosphere(biosphere),
+		Population: content.ParsePopulation(population),
+		TechLevel:  content.ParseTechLevel(tl),
+	}
+
+	for i, t := range tags {
+		w.Tags[i] = content.Tag{Name: t}
+	}
+
+	return w
+}
+
+func TestQuery(t *testing.T) {
+	s := &Stars{
+		Rows: 10,
+		Cols: 8,
+		Systems: []*Star{
+			{
+				Name:   "Aleph",
+				Worlds: []content.World{queryWorld("Aleph", "breathable", "humanMiscible", "billions", "TL4", culture.English, "Abandoned Colony", "Trade Hub")},
+				POIs:   []content.POI{{Point: "Deep-space station"}},
+			},
+			{
+				Name: "Beth",
+				Col:  2,
+				Worlds: []content.World{
+					queryWorld("Beth", "airless", "noBiosphere", "outpost", "TL4+", culture.Chinese, "Trade Hub", "Zombies"),
+					queryWorld("Beth II", "thick", "microbial", "severalMillion", "TL2", culture.English, "Xenophiles", "Zombies"),
+				},
+			},
+			{
+				Name:   "Gimel",
+				Col:    4,
+				Worlds: []content.World{queryWorld("Gimel", "breathable", "hybrid", "fewerThanMillion", "TL5", culture.Greek, "Abandoned Colony", "Sealed Menace")},
+				POIs:   []content.POI{{Point: "Gas giant mine"}},
+			},
+		},
+	}
+
+	for _, tc := range []struct {
+		name string
+		q    Query
+		want []string // World names in order
+	}{
+		{"everything", Query{}, []string{"Aleph", "Beth", "Beth II", "Gimel"}},
+		{"one tag", Query{Tags: []string{"trade hub"}}, []string{"Aleph", "Beth"}},
+		{"every tag", Query{Tags: []string{"Trade Hub", "Zombies"}}, []string{"Beth"}},
+		{"tech level", Query{TechLevel: "tl4+"}, []string{"Beth"}},
+		{"minimum tech level", Query{MinTL: "TL4+"}, []string{"Beth", "Gimel"}},
+		{"atmosphere text", Query{Atmosphere: "BREATHABLE"}, []string{"Aleph", "Beth II", "Gimel"}}, // A thick atmosphere is breathable with a mask
+		{"atmosphere full text", Query{Atmosphere: "breathable mix"}, []string{"Aleph", "Gimel"}},
+		{"biosphere code", Query{Biosphere: "humanMiscible"}, []string{"Aleph"}},
+		{"population", Query{Population: "million"}, []string{"Beth II", "Gimel"}},
+		{"culture", Query{Culture: "english"}, []string{"Aleph", "Beth II"}},
+		{"poi", Query{POI: "station"}, []string{"Aleph"}},
+		{"combined", Query{Tags: []string{"Abandoned Colony"}, MinTL: "TL4", Atmosphere: "breathable", POI: "mine"}, []string{"Gimel"}},
+		{"no match", Query{Culture: "Russian"}, nil},
+	} {
+		res, err := s.Query(tc.q)
+		if err != nil {
+			t.Errorf("%s: %s", tc.name, err)
+			continue
+		}
+
+		var got []string
+		for _, r := range res {
+			got = append(got, r.World.Name)
+
+			found := false
+			for _, w := range r.Star.Worlds {
+				found = found || w.Name == r.World.Name
+			}
+			if !found {
+				t.Errorf("%s: %s returned with system %s", tc.name, r.World.Name, r.Star.Name)
+			}
+		}
+
+		if strings.Join(got, ", ") != strings.Join(tc.want, ", ") {
+			t.Errorf("%s: found %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestQueryErrors(t *testing.T) {
+	s := &Stars{Rows: 10, Cols: 8}
+
+	for _, q := range []Query{
+		{TechLevel: "TL6"},
+		{MinTL: "modern"},
+		{Culture: "Martian"},
+	} {
+		if _, err := s.Query(q); err == nil {
+			t.Errorf("query %+v returned no error", q)
+		}
+	}
+}
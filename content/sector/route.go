@@ -0,0 +1,106 @@
+package sector
+
+import "fmt"
+
+// Spike drill constants. A drill takes 6 days per hex travelled divided by the drive rating, and a
+// drill course gets harder to plot the further it reaches.
+const (
+	drillDaysPerHex      = 6.0
+	drillBaseDifficulty  = 7
+	drillExtraDifficulty = 1 // Added for each hex past the first
+)
+
+// Jump is a single spike drill between two systems
+type Jump struct {
+	From, To   *Star
+	Hexes      int
+	Days       float64
+	Difficulty int
+}
+
+func newJump(from, to *Star, drive int) Jump {
+	hexes := from.Hex().Distance(to.Hex())
+
+	return Jump{
+		From:       from,
+		To:         to,
+		Hexes:      hexes,
+		Days:       drillDaysPerHex * float64(hexes) / float64(drive),
+		Difficulty: drillBaseDifficulty + drillExtraDifficulty*(hexes-1),
+	}
+}
+
+// Route returns the quickest series of jumps between the systems named from and to for a ship with
+// a spike drive rating of drive. A ship can reach any system within drive hexes in a single jump.
+// Where routes take the same time the one with the fewest jumps is preferred.
+func (s *Stars) Route(from, to string, drive int) ([]Jump, error) {
+	if drive < 1 {
+		return nil, fmt.Errorf("spike drive rating must be at least 1")
+	}
+
+	start, err := s.Find(from)
+	if err != nil {
+		return nil, err
+	}
+
+	end, err := s.Find(to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Travel time is proportional to hexes travelled so a Dijkstra search on hex distance, with the
+	// number of jumps breaking ties, finds the quickest route.
+	type cost struct{ hexes, jumps int }
+
+	var (
+		best    = map[*Star]cost{start: {}}
+		prev    = make(map[*Star]*Star)
+		visited = make(map[*Star]bool)
+	)
+
+	for {
+		var (
+			cur *Star
+			c   cost
+		)
+
+		for _, star := range s.Systems {
+			b, ok := best[star]
+			if !ok || visited[star] {
+				continue
+			}
+
+			if cur == nil || b.hexes < c.hexes || (b.hexes == c.hexes && b.jumps < c.jumps) {
+				cur, c = star, b
+			}
+		}
+
+		if cur == nil {
+			return nil, fmt.Errorf("%s cannot be reached from %s with a drive rating of %d", end.Name, start.Name, drive)
+		}
+
+		if cur == end {
+			break
+		}
+
+		visited[cur] = true
+		for _, star := range s.Systems {
+			d := cur.Hex().Distance(star.Hex())
+			if visited[star] || d == 0 || d > drive {
+				continue
+			}
+
+			n := cost{c.hexes + d, c.jumps + 1}
+			if b, ok := best[star]; !ok || n.hexes < b.hexes || (n.hexes == b.hexes && n.jumps < b.jumps) {
+				best[star], prev[star] = n, cur
+			}
+		}
+	}
+
+	var jumps []Jump
+	for star := end; star != start; star = prev[star] {
+		jumps = append([]Jump{newJump(prev[star], star, drive)}, jumps...)
+	}
+
+	return jumps, nil
+}
@@ -0,0 +1,81 @@
+package sector
+
+import "testing"
+
+func TestRoute(t *testing.T) {
+	s := &Stars{
+		Rows: 10,
+		Cols: 10,
+		Systems: []*Star{
+			{Name: "Aleph", Row: 0, Col: 0},
+			{Name: "Beth", Row: 0, Col: 2},
+			{Name: "Gimel", Row: 0, Col: 4},
+			{Name: "Daleth", Row: 0, Col: 8},
+		},
+	}
+
+	type jump struct {
+		from, to   string
+		hexes      int
+		days       float64
+		difficulty int
+	}
+
+	for _, tc := range []struct {
+		from, to string
+		drive    int
+		want     []jump
+	}{
+		{"Aleph", "Gimel", 2, []jump{{"Aleph", "Beth", 2, 6, 8}, {"Beth", "Gimel", 2, 6, 8}}},
+		{"Aleph", "Gimel", 3, []jump{{"Aleph", "Beth", 2, 4, 8}, {"Beth", "Gimel", 2, 4, 8}}},
+		{"Aleph", "Gimel", 4, []jump{{"Aleph", "Gimel", 4, 6, 10}}}, // Same time, fewer jumps
+		{"gimel", "aleph", 6, []jump{{"Gimel", "Aleph", 4, 4, 10}}},
+		{"Aleph", "Daleth", 4, []jump{{"Aleph", "Gimel", 4, 6, 10}, {"Gimel", "Daleth", 4, 6, 10}}},
+		{"Beth", "Beth", 1, nil},
+	} {
+		jumps, err := s.Route(tc.from, tc.to, tc.drive)
+		if err != nil {
+			t.Errorf("%s to %s with drive %d: %s", tc.from, tc.to, tc.drive, err)
+			continue
+		}
+
+		var got []jump
+		for _, j := range jumps {
+			got = append(got, jump{j.From.Name, j.To.Name, j.Hexes, j.Days, j.Difficulty})
+		}
+
+		if len(got) != len(tc.want) {
+			t.Errorf("%s to %s with drive %d = %v, want %v", tc.from, tc.to, tc.drive, got, tc.want)
+			continue
+		}
+
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("%s to %s with drive %d = %v, want %v", tc.from, tc.to, tc.drive, got, tc.want)
+				break
+			}
+		}
+	}
+}
+
+func TestRouteErrors(t *testing.T) {
+	s := &Stars{
+		Rows:    10,
+		Cols:    10,
+		Systems: []*Star{{Name: "Aleph", Row: 0, Col: 0}, {Name: "Beth", Row: 0, Col: 3}},
+	}
+
+	for _, tc := range []struct {
+		from, to string
+		drive    int
+	}{
+		{"Aleph", "Beth", 0},  // No drive
+		{"Aleph", "Beth", 2},  // Out of range
+		{"Aleph", "Zayin", 3}, // Unknown system
+		{"Zayin", "Aleph", 3}, // Unknown system
+	} {
+		if _, err := s.Route(tc.from, tc.to, tc.drive); err == nil {
+			t.Errorf("%s to %s with drive %d returned no error", tc.from, tc.to, tc.drive)
+		}
+	}
+}
@@ -4,19 +4,15 @@ package sector
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
-	"time"
+	"strings"
 
 	"github.com/nboughton/swnt/content"
 	"github.com/nboughton/swnt/content/culture"
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/name"
+	"github.com/nboughton/swnt/dice"
 )
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // Star represents a single Star on the Sector map
 type Star struct {
 	Row, Col int
@@ -27,29 +23,38 @@ type Star struct {
 }
 
 // NewStar generates a new Star struct to be added to the map
-func NewStar(row, col int, name string, exclude []string, fullTags bool, poiChance, otherWorldChance int) *Star {
-	ctr := culture.Random()
+func NewStar(rng *dice.Rand, row, col int, name string, exclude []string, fullTags bool, poiChance, otherWorldChance int) (*Star, error) {
+	ctr := culture.Random(rng)
+
+	w, err := content.NewWorld(rng, true, ctr, fullTags, exclude)
+	if err != nil {
+		return nil, err
+	}
 
 	s := &Star{
 		Row:     row,
 		Col:     col,
 		Culture: ctr,
 		Name:    name,
-		Worlds:  []content.World{content.NewWorld(true, ctr, fullTags, exclude)},
+		Worlds:  []content.World{w},
 	}
 
 	// Cascading 10% chance of other worlds
-	for rand.Intn(100) < otherWorldChance {
-		ctr = culture.Random()
-		s.Worlds = append(s.Worlds, content.NewWorld(false, ctr, fullTags, exclude))
+	for rng.Intn(100) < otherWorldChance {
+		ctr = culture.Random(rng)
+		if w, err = content.NewWorld(rng, false, ctr, fullTags, exclude); err != nil {
+			return nil, err
+		}
+
+		s.Worlds = append(s.Worlds, w)
 	}
 
 	// 30% chance of a Point of Interest
-	if rand.Intn(100) < poiChance {
-		s.POIs = append(s.POIs, content.NewPOI())
+	if rng.Intn(100) < poiChance {
+		s.POIs = append(s.POIs, content.NewPOI(rng))
 	}
 
-	return s
+	return s, nil
 }
 
 // Format returns the details of a Star formatted as type t
@@ -79,8 +84,11 @@ func (s *Star) Format(t format.OutputType) string {
 
 // Stars represents the generated collection of Stars that will be used to populate a hex grid
 type Stars struct {
+	Seed       int64 // Seed that the random source was set to when the sector was generated
 	Rows, Cols int
 	Systems    []*Star
+	Lanes      Lanes
+	Factions   []content.Faction
 }
 
 // Density of star systems in a sector
@@ -93,10 +101,38 @@ const (
 	DENSE
 )
 
+// ParseDensity returns the Density called s, either sparse, average or dense
+func ParseDensity(s string) (Density, error) {
+	switch strings.ToLower(s) {
+	case "sparse":
+		return SPARSE, nil
+	case "average":
+		return AVERAGE, nil
+	case "dense":
+		return DENSE, nil
+	}
+
+	return AVERAGE, fmt.Errorf("unknown density value [%s], use sparse, average or dense", s)
+}
+
+// Smallest and largest sectors supported, in hexes in either direction
+const (
+	MinSize = 2
+	MaxSize = 99
+)
+
 // NewSector returns a blank Sector struct and generates tag information according to the guidelines
-// in pages 133 - 177 of Stars Without Number (Revised Edition).
-func NewSector(rows, cols int, excludeTags []string, fullTags bool, poiChance, otherWorldChance int, density Density) *Stars {
+// in pages 133 - 177 of Stars Without Number (Revised Edition). The sector is drawn from rng and
+// records its seed, calling NewSector with dice.New(Seed) and the same arguments reproduces it. An
+// error is returned if rows or cols are outside MinSize to MaxSize or excludeTags leaves too few
+// world tags.
+func NewSector(rng *dice.Rand, rows, cols int, excludeTags []string, fullTags bool, poiChance, otherWorldChance int, density Density) (*Stars, error) {
+	if rows < MinSize || rows > MaxSize || cols < MinSize || cols > MaxSize {
+		return nil, fmt.Errorf("sectors larger than %d, or smaller than %d, hexes in either direction are not supported", MaxSize, MinSize)
+	}
+
 	s := &Stars{
+		Seed: rng.CurrentSeed(),
 		Rows: rows,
 		Cols: cols,
 	}
@@ -112,33 +148,39 @@ func NewSector(rows, cols int, excludeTags []string, fullTags bool, poiChance, o
 	}
 
 	cells := s.Rows * s.Cols
-	stars := (rand.Intn(cells/4) / 2) + (cells / dVal)
+	stars := (rng.Intn(cells/4) / 2) + (cells / dVal)
 
-	for row, col := rand.Intn(s.Rows), rand.Intn(s.Cols); len(s.Systems) <= stars; row, col = rand.Intn(s.Rows), rand.Intn(s.Cols) {
+	for row, col := rng.Intn(s.Rows), rng.Intn(s.Cols); len(s.Systems) <= stars; row, col = rng.Intn(s.Rows), rng.Intn(s.Cols) {
 		if !s.active(row, col) {
-			s.Systems = append(s.Systems, NewStar(row, col, s.systemName(), excludeTags, fullTags, poiChance, otherWorldChance))
+			star, err := NewStar(rng, row, col, s.systemName(rng), excludeTags, fullTags, poiChance, otherWorldChance)
+			if err != nil {
+				return nil, err
+			}
+
+			s.Systems = append(s.Systems, star)
 		}
 	}
 
-	return s
+	return s, nil
 }
 
 // UniqueName ensures rolls on the name.System table until it gets a name that is not currently in use.
-func (s *Stars) systemName() string {
+func (s *Stars) systemName(rng *dice.Rand) string {
 	//n := name.System.Roll() // Try system first
-	n := name.Generate(rand.Intn(4) + 3)
+	n := name.Generate(rng, rng.Intn(4)+3)
 	for {
-		if !s.nameUsed(n) {
+		if !s.NameUsed(n, nil) {
 			return n
 		}
 
-		n = name.Generate(rand.Intn(4) + 3)
+		n = name.Generate(rng, rng.Intn(4)+3)
 	}
 }
 
-func (s *Stars) nameUsed(n string) bool {
+// NameUsed reports whether a Star other than except is called n, ignoring case as Find does
+func (s *Stars) NameUsed(n string, except *Star) bool {
 	for _, star := range s.Systems {
-		if star.Name == n {
+		if star != except && strings.EqualFold(star.Name, n) {
 			return true
 		}
 	}
@@ -0,0 +1,382 @@
+package content
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/content/culture"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/gender"
+	"github.com/nboughton/swnt/dice"
+)
+
+// HullClass is the size class of a starship hull. Larger classes can mount larger components.
+type HullClass int
+
+// HullClass constants
+const (
+	Fighter HullClass = iota
+	Frigate
+	Cruiser
+	Capital
+)
+
+// HullClasses supported
+var HullClasses = []HullClass{Fighter, Frigate, Cruiser, Capital}
+
+func (c HullClass) String() string {
+	switch c {
+	case Frigate:
+		return "Frigate"
+	case Cruiser:
+		return "Cruiser"
+	case Capital:
+		return "Capital"
+	}
+
+	return "Fighter"
+}
+
+// MarshalText satisfies encoding.TextMarshaler so that hull classes are written to JSON by name
+func (c HullClass) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText satisfies encoding.TextUnmarshaler
+func (c *HullClass) UnmarshalText(b []byte) error {
+	for _, h := range HullClasses {
+		if strings.ToLower(h.String()) == strings.ToLower(string(b)) {
+			*c = h
+			return nil
+		}
+	}
+
+	return fmt.Errorf("no hull class found for \"%s\", options available are %s", b, HullClasses)
+}
+
+// multiplier is applied to the power and mass of components marked as scaling with hull size
+func (c HullClass) multiplier() int {
+	return int(c) + 1
+}
+
+// FindHullClass returns the HullClass named n or a random class if n is empty
+func FindHullClass(rng *dice.Rand, n string) (HullClass, error) {
+	if n == "" {
+		return HullClasses[rng.Intn(len(HullClasses))], nil
+	}
+
+	for _, c := range HullClasses {
+		if strings.ToLower(c.String()) == strings.ToLower(n) {
+			return c, nil
+		}
+	}
+
+	return Fighter, fmt.Errorf("no hull class found for \"%s\", options available are %s", n, HullClasses)
+}
+
+// Hull is the frame of a starship and determines its budgets for power, mass and hardpoints
+type Hull struct {
+	Name       string
+	Class      HullClass
+	HP         int
+	AC         int
+	Armor      int
+	Speed      int
+	CrewMin    int
+	CrewMax    int
+	Power      int
+	Mass       int
+	Hardpoints int
+}
+
+// ShipComponent is a weapon, defence or fitting installed on a starship
+type ShipComponent struct {
+	Name       string
+	Effect     string // Damage and qualities for weapons, a summary of the effect for everything else
+	Power      int
+	Mass       int
+	Hardpoints int
+	MinClass   HullClass
+	Scaled     bool // Power and mass are multiplied by the hull class
+}
+
+// CrewMember is a notable member of a starship's crew
+type CrewMember struct {
+	Role string
+	NPC  NPC
+}
+
+// Starship represents a complete starship as built with the rules of Stars Without Number (Revised Edition)
+type Starship struct {
+	Name     string
+	Hull     Hull
+	Crew     int
+	Weapons  []ShipComponent
+	Defences []ShipComponent
+	Fittings []ShipComponent
+	Roster   []CrewMember
+}
+
+// NewStarship creates a random starship with a hull of class c. Weapons, defences and fittings are
+// installed within the power, mass and hardpoint budgets of the hull and the notable crew are drawn
+// from culture ctr.
+func NewStarship(rng *dice.Rand, c HullClass, ctr culture.Culture) Starship {
+	var hulls []Hull
+	for _, h := range shipHulls {
+		if h.Class == c {
+			hulls = append(hulls, h)
+		}
+	}
+
+	s := Starship{
+		Name: fmt.Sprintf("%s %s", rng.Roll(shipTable.adjective), rng.Roll(shipTable.noun)),
+		Hull: hulls[rng.Intn(len(hulls))],
+	}
+	s.Crew = s.Hull.CrewMin + rng.Intn(s.Hull.CrewMax-s.Hull.CrewMin+1)
+
+	// Larger hulls are fitted out with more components, as far as their budgets allow
+	n := c.multiplier()
+	for i := 0; i < n; i++ {
+		s.install(rng, &s.Weapons, shipWeapons)
+	}
+
+	for i := rng.Intn(n + 1); i > 0; i-- {
+		s.install(rng, &s.Defences, shipDefences)
+	}
+
+	for i := 0; i < n+1; i++ {
+		s.install(rng, &s.Fittings, shipFittings)
+	}
+
+	roles := shipRoles[c]
+	if len(roles) > s.Crew {
+		roles = roles[:s.Crew]
+	}
+
+	for _, r := range roles {
+		s.Roster = append(s.Roster, CrewMember{Role: r, NPC: NewNPC(rng, ctr, gender.Random(rng), false)})
+	}
+
+	return s
+}
+
+// Used returns the power, mass and hardpoints used by the ship's components
+func (s Starship) Used() (power, mass, hardpoints int) {
+	for _, set := range [][]ShipComponent{s.Weapons, s.Defences, s.Fittings} {
+		for _, c := range set {
+			power += c.Power
+			mass += c.Mass
+			hardpoints += c.Hardpoints
+		}
+	}
+
+	return power, mass, hardpoints
+}
+
+// install adds a random component from options to set if one fits the hull and its remaining
+// budgets. Components are not installed twice.
+func (s *Starship) install(rng *dice.Rand, set *[]ShipComponent, options []ShipComponent) {
+	power, mass, hardpoints := s.Used()
+
+	var fits []ShipComponent
+	for _, o := range options {
+		if o.MinClass > s.Hull.Class || installed(*set, o.Name) {
+			continue
+		}
+
+		if o.Scaled {
+			o.Power *= s.Hull.Class.multiplier()
+			o.Mass *= s.Hull.Class.multiplier()
+		}
+
+		if power+o.Power <= s.Hull.Power && mass+o.Mass <= s.Hull.Mass && hardpoints+o.Hardpoints <= s.Hull.Hardpoints {
+			fits = append(fits, o)
+		}
+	}
+
+	if len(fits) > 0 {
+		*set = append(*set, fits[rng.Intn(len(fits))])
+	}
+}
+
+func installed(set []ShipComponent, n string) bool {
+	for _, c := range set {
+		if c.Name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Format returns the starship formatted as type t
+func (s Starship) Format(t format.OutputType) string {
+	var (
+		buf                     = new(bytes.Buffer)
+		power, mass, hardpoints = s.Used()
+		h                       = s.Hull
+	)
+
+	fmt.Fprintf(buf, format.Table(t, []string{s.Name, ""}, [][]string{
+		{"Hull", fmt.Sprintf("%s (%s)", h.Name, h.Class)},
+		{"HP", strconv.Itoa(h.HP)},
+		{"AC", strconv.Itoa(h.AC)},
+		{"Armor", strconv.Itoa(h.Armor)},
+		{"Speed", strconv.Itoa(h.Speed)},
+		{"Crew", fmt.Sprintf("%d (%d-%d)", s.Crew, h.CrewMin, h.CrewMax)},
+		{"Power", fmt.Sprintf("%d/%d", power, h.Power)},
+		{"Mass", fmt.Sprintf("%d/%d", mass, h.Mass)},
+		{"Hardpoints", fmt.Sprintf("%d/%d", hardpoints, h.Hardpoints)},
+	}))
+
+	for _, set := range []struct {
+		header     string
+		components []ShipComponent
+	}{
+		{"Weapon", s.Weapons},
+		{"Defence", s.Defences},
+		{"Fitting", s.Fittings},
+	} {
+		if len(set.components) == 0 {
+			continue
+		}
+
+		var rows [][]string
+		for _, c := range set.components {
+			rows = append(rows, []string{c.Name, c.Effect, strconv.Itoa(c.Power), strconv.Itoa(c.Mass), strconv.Itoa(c.Hardpoints)})
+		}
+
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, format.Table(t, []string{set.header, "Effect", "Power", "Mass", "Hardpoints"}, rows))
+	}
+
+	if len(s.Roster) > 0 {
+		var rows [][]string
+		for _, c := range s.Roster {
+			rows = append(rows, []string{c.Role, c.NPC.Name, c.NPC.Gender.String(), c.NPC.Hooks.Manner, c.NPC.Hooks.Motivation})
+		}
+
+		fmt.Fprintln(buf)
+		fmt.Fprintf(buf, format.Table(t, []string{"Crew", "Name", "Gender", "Manner", "Motivation"}, rows))
+	}
+
+	return buf.String()
+}
+
+func (s Starship) String() string {
+	return s.Format(format.TEXT)
+}
+
+// shipRoles lists the notable crew positions of each hull class, in order of importance
+var shipRoles = map[HullClass][]string{
+	Fighter: {"Pilot", "Gunner", "Passenger"},
+	Frigate: {"Captain", "Pilot", "Engineer", "Gunnery Chief", "Comms Officer"},
+	Cruiser: {"Captain", "Executive Officer", "Pilot", "Chief Engineer", "Gunnery Chief", "Comms Officer", "Medical Officer"},
+	Capital: {"Admiral", "Captain", "Executive Officer", "Navigator", "Chief Engineer", "Gunnery Chief", "Comms Officer", "Medical Officer", "Security Chief"},
+}
+
+// shipHulls from the starship creation rules of SWN Revised Free Edition
+var shipHulls = []Hull{
+	{Name: "Strike Fighter", Class: Fighter, HP: 8, AC: 16, Armor: 5, Speed: 5, CrewMin: 1, CrewMax: 1, Power: 5, Mass: 2, Hardpoints: 1},
+	{Name: "Shuttle", Class: Fighter, HP: 15, AC: 11, Armor: 0, Speed: 3, CrewMin: 1, CrewMax: 10, Power: 3, Mass: 5, Hardpoints: 1},
+	{Name: "Free Merchant", Class: Frigate, HP: 20, AC: 14, Armor: 2, Speed: 3, CrewMin: 1, CrewMax: 6, Power: 10, Mass: 15, Hardpoints: 2},
+	{Name: "Patrol Boat", Class: Frigate, HP: 25, AC: 14, Armor: 5, Speed: 4, CrewMin: 5, CrewMax: 20, Power: 15, Mass: 10, Hardpoints: 4},
+	{Name: "Corvette", Class: Frigate, HP: 40, AC: 13, Armor: 10, Speed: 2, CrewMin: 10, CrewMax: 40, Power: 15, Mass: 15, Hardpoints: 6},
+	{Name: "Heavy Frigate", Class: Frigate, HP: 50, AC: 15, Armor: 10, Speed: 1, CrewMin: 30, CrewMax: 120, Power: 25, Mass: 20, Hardpoints: 8},
+	{Name: "Bulk Freighter", Class: Cruiser, HP: 40, AC: 11, Armor: 0, Speed: 0, CrewMin: 10, CrewMax: 40, Power: 15, Mass: 25, Hardpoints: 2},
+	{Name: "Fleet Cruiser", Class: Cruiser, HP: 60, AC: 14, Armor: 15, Speed: 1, CrewMin: 50, CrewMax: 200, Power: 50, Mass: 30, Hardpoints: 10},
+	{Name: "Battleship", Class: Capital, HP: 100, AC: 16, Armor: 20, Speed: 0, CrewMin: 200, CrewMax: 1000, Power: 75, Mass: 50, Hardpoints: 15},
+	{Name: "Carrier", Class: Capital, HP: 75, AC: 14, Armor: 10, Speed: 0, CrewMin: 300, CrewMax: 1500, Power: 50, Mass: 100, Hardpoints: 4},
+}
+
+// shipWeapons from the starship creation rules of SWN Revised Free Edition
+var shipWeapons = []ShipComponent{
+	{Name: "Multifocal Laser", Effect: "1d4, AP 20", Power: 5, Mass: 1, Hardpoints: 1, MinClass: Fighter},
+	{Name: "Reaper Battery", Effect: "3d4, Clumsy", Power: 4, Mass: 1, Hardpoints: 1, MinClass: Fighter},
+	{Name: "Fractal Impact Charge", Effect: "2d6, AP 15, Ammo 4", Power: 5, Mass: 1, Hardpoints: 1, MinClass: Fighter},
+	{Name: "Polyspectral MES Beam", Effect: "2d4, AP 25", Power: 5, Mass: 1, Hardpoints: 1, MinClass: Fighter},
+	{Name: "Sandthrower", Effect: "2d4, Flak", Power: 3, Mass: 1, Hardpoints: 1, MinClass: Fighter},
+	{Name: "Flak Emitter Battery", Effect: "2d6, AP 10, Flak", Power: 5, Mass: 3, Hardpoints: 1, MinClass: Frigate},
+	{Name: "Torpedo Launcher", Effect: "3d8, AP 20, Ammo 4", Power: 10, Mass: 3, Hardpoints: 1, MinClass: Frigate},
+	{Name: "Charged Particle Caster", Effect: "3d6, AP 15, Clumsy", Power: 10, Mass: 1, Hardpoints: 2, MinClass: Frigate},
+	{Name: "Plasma Beam", Effect: "3d6, AP 10", Power: 5, Mass: 2, Hardpoints: 2, MinClass: Frigate},
+	{Name: "Mag Spike Array", Effect: "2d6+2, AP 10, Flak, Ammo 5", Power: 5, Mass: 2, Hardpoints: 2, MinClass: Frigate},
+	{Name: "Spinal Beam Cannon", Effect: "3d10, AP 15, Clumsy", Power: 10, Mass: 5, Hardpoints: 3, MinClass: Cruiser},
+	{Name: "Smart Cloud", Effect: "3d10, Cloud, Clumsy", Power: 10, Mass: 5, Hardpoints: 2, MinClass: Cruiser},
+	{Name: "Gravcannon", Effect: "4d6, AP 20", Power: 15, Mass: 4, Hardpoints: 3, MinClass: Cruiser},
+	{Name: "Spike Inversion Projector", Effect: "3d8, AP 15", Power: 10, Mass: 3, Hardpoints: 3, MinClass: Cruiser},
+	{Name: "Vortex Tunnel Inductor", Effect: "3d20, AP 20, Clumsy", Power: 20, Mass: 10, Hardpoints: 4, MinClass: Capital},
+	{Name: "Mass Cannon", Effect: "2d20, AP 20, Ammo 4", Power: 10, Mass: 5, Hardpoints: 4, MinClass: Capital},
+	{Name: "Lightning Charge Mantle", Effect: "1d20, AP 5, Cloud", Power: 15, Mass: 5, Hardpoints: 2, MinClass: Capital},
+	{Name: "Singularity Gun", Effect: "5d20, AP 25", Power: 25, Mass: 10, Hardpoints: 5, MinClass: Capital},
+}
+
+// shipDefences from the starship creation rules of SWN Revised Free Edition
+var shipDefences = []ShipComponent{
+	{Name: "Augmented Plating", Effect: "+2 AC, -1 Speed", Power: 0, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Hardened Polyceramic Overlay", Effect: "Reduces the AP of weapons by 5", Power: 0, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Boarding Countermeasures", Effect: "Makes boarding the ship more difficult", Power: 2, Mass: 1, MinClass: Frigate, Scaled: true},
+	{Name: "Burst ECM Generator", Effect: "Negate one successful hit, once per fight", Power: 2, Mass: 1, MinClass: Frigate, Scaled: true},
+	{Name: "Grav Eddy Displacer", Effect: "1 in 6 chance of a weapon attack missing", Power: 5, Mass: 2, MinClass: Frigate, Scaled: true},
+	{Name: "Planetary Defense Array", Effect: "Surface-to-orbit batteries defend the ship", Power: 4, Mass: 2, MinClass: Frigate, Scaled: true},
+	{Name: "Point Defense Lasers", Effect: "+2 AC against weapons with the Ammo quality", Power: 3, Mass: 2, MinClass: Frigate, Scaled: true},
+	{Name: "Foxer Drones", Effect: "+2 AC for one round, Ammo 5", Power: 2, Mass: 1, MinClass: Cruiser, Scaled: true},
+	{Name: "Ablative Hull Compartments", Effect: "+1 AC, +20 maximum HP", Power: 5, Mass: 2, MinClass: Capital, Scaled: true},
+}
+
+// shipFittings from the starship creation rules of SWN Revised Free Edition
+var shipFittings = []ShipComponent{
+	{Name: "Atmospheric Configuration", Effect: "Can land on worlds with an atmosphere", Power: 0, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Auto-targeting System", Effect: "Weapons fire without a gunner", Power: 1, Mass: 0, MinClass: Fighter},
+	{Name: "Automation Support", Effect: "Expert systems fill crew positions", Power: 2, Mass: 1, MinClass: Fighter},
+	{Name: "Cargo Space", Effect: "Pressurized cargo hold", Power: 0, Mass: 1, MinClass: Fighter},
+	{Name: "Drive-2 Upgrade", Effect: "Spike drive rating increased to 2", Power: 1, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Emissions Dampers", Effect: "+2 to skill checks to avoid detection", Power: 1, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Extended Life Support", Effect: "Doubles the maximum crew", Power: 1, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Extended Stores", Effect: "Doubles the ship's supply endurance", Power: 0, Mass: 1, MinClass: Fighter, Scaled: true},
+	{Name: "Fuel Bunkers", Effect: "Fuel for one more drill before refueling", Power: 0, Mass: 1, MinClass: Fighter},
+	{Name: "Smuggler's Hold", Effect: "Concealed cargo space", Power: 0, Mass: 1, MinClass: Fighter},
+	{Name: "Armory", Effect: "Weapons and armor for the crew", Power: 0, Mass: 0, MinClass: Frigate},
+	{Name: "Boarding Tubes", Effect: "Allows boarding of disabled ships", Power: 0, Mass: 1, MinClass: Frigate},
+	{Name: "Cargo Lighter", Effect: "Orbit-to-surface cargo shuttle", Power: 0, Mass: 2, MinClass: Frigate},
+	{Name: "Drill Course Regulator", Effect: "-2 difficulty to spike drill checks", Power: 1, Mass: 1, MinClass: Frigate, Scaled: true},
+	{Name: "Extended Medbay", Effect: "Can provide long-term medical care", Power: 1, Mass: 1, MinClass: Frigate},
+	{Name: "Fuel Scoops", Effect: "Can refuel from a gas giant", Power: 2, Mass: 1, MinClass: Frigate, Scaled: true},
+	{Name: "Lifeboats", Effect: "Emergency escape pods for the crew", Power: 0, Mass: 1, MinClass: Frigate, Scaled: true},
+	{Name: "Luxury Cabins", Effect: "Quarters fit for wealthy passengers", Power: 1, Mass: 1, MinClass: Frigate, Scaled: true},
+	{Name: "Mobile Extractor", Effect: "Can mine asteroids for fuel and materials", Power: 2, Mass: 1, MinClass: Frigate},
+	{Name: "Precognitive Navigation Chamber", Effect: "A psychic navigator aids spike drills", Power: 1, Mass: 0, MinClass: Frigate},
+	{Name: "Sensor Mask", Effect: "Disguises the ship as another type", Power: 1, Mass: 0, MinClass: Frigate, Scaled: true},
+	{Name: "Ship's Locker", Effect: "General equipment for the crew", Power: 0, Mass: 0, MinClass: Frigate},
+	{Name: "Shiptender Mount", Effect: "Can carry a smaller ship", Power: 1, Mass: 1, MinClass: Frigate},
+	{Name: "Survey Sensor Array", Effect: "+2 to survey and sensor checks", Power: 2, Mass: 1, MinClass: Frigate},
+	{Name: "Teleportation Pads", Effect: "Pretech teleporters to the surface", Power: 1, Mass: 1, MinClass: Frigate},
+	{Name: "Tractor Beams", Effect: "Can manipulate objects in space", Power: 2, Mass: 1, MinClass: Frigate},
+	{Name: "Workshop", Effect: "Space and tools for repairs and modding", Power: 1, Mass: 0, MinClass: Frigate},
+	{Name: "Hydroponic Production", Effect: "Grows supplies for the crew", Power: 1, Mass: 2, MinClass: Cruiser, Scaled: true},
+	{Name: "Mobile Factory", Effect: "Can manufacture goods and parts", Power: 3, Mass: 2, MinClass: Cruiser, Scaled: true},
+	{Name: "Ship Bay", Effect: "Hangar for a fighter class ship", Power: 0, Mass: 2, MinClass: Cruiser},
+}
+
+var shipTable = struct {
+	adjective roll.List
+	noun      roll.List
+}{
+	roll.List{
+		Name: "Adjective",
+		Items: []string{
+			"Bright", "Crimson", "Dauntless", "Distant", "Errant", "Fortunate", "Gilded", "Indomitable", "Last",
+			"Lucky", "Midnight", "Quiet", "Restless", "Silver", "Swift", "Undying", "Valiant", "Wandering",
+		},
+	},
+	roll.List{
+		Name: "Noun",
+		Items: []string{
+			"Comet", "Dawn", "Fortune", "Hammer", "Harbinger", "Horizon", "Lance", "Mercy", "Nomad", "Promise",
+			"Raven", "Sparrow", "Spear", "Star", "Tempest", "Vengeance", "Voyager", "Wraith",
+		},
+	},
+}
@@ -0,0 +1,262 @@
+package table
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/nboughton/go-roll"
+	"gopkg.in/yaml.v2"
+)
+
+// Definition is the file format of a user defined table. A table with a List of items rolls each
+// item with equal probability, otherwise the Dice (i.e "2d6") are rolled and matched against Items.
+//
+//	id: homebrew.Weather
+//	name: Weather
+//	dice: 2d6
+//	items:
+//	  - match: 2-4
+//	    text: Storms
+//	  - match: 5-12
+//	    text: Clear skies
+type Definition struct {
+	ID    string           `yaml:"id"`
+	Name  string           `yaml:"name"`
+	Dice  string           `yaml:"dice"`
+	Items []DefinitionItem `yaml:"items"`
+	List  []string         `yaml:"list"`
+}
+
+// DefinitionItem is a single entry of a Definition. Match is a number, a range (3-5) or a comma
+// separated set of numbers (6,7,8).
+type DefinitionItem struct {
+	Match string `yaml:"match"`
+	Text  string `yaml:"text"`
+}
+
+// Table converts the Definition to a roll.Table. Lists are converted to tables rolled with a 1dN
+// where N is the number of items.
+func (d Definition) Table() (roll.Table, error) {
+	if d.ID == "" {
+		return roll.Table{}, fmt.Errorf("table has no id")
+	}
+
+	t := roll.Table{ID: d.ID, Name: d.Name}
+	if t.Name == "" {
+		t.Name = d.ID
+	}
+
+	if len(d.List) > 0 {
+		t.Dice = roll.Dice{N: 1, Die: die(len(d.List))}
+		for i, item := range d.List {
+			t.Items = append(t.Items, roll.TableItem{Match: []int{i + 1}, Text: item})
+		}
+
+		return t, nil
+	}
+
+	if len(d.Items) == 0 {
+		return roll.Table{}, fmt.Errorf("table %s has no items", d.ID)
+	}
+
+	dice, err := parseDice(d.Dice)
+	if err != nil {
+		return roll.Table{}, fmt.Errorf("table %s: %s", d.ID, err)
+	}
+	t.Dice = dice
+
+	for _, item := range d.Items {
+		m, err := parseMatch(item.Match)
+		if err != nil {
+			return roll.Table{}, fmt.Errorf("table %s: %s", d.ID, err)
+		}
+
+		t.Items = append(t.Items, roll.TableItem{Match: m, Text: item.Text})
+	}
+
+	if err := checkMatches(t); err != nil {
+		return roll.Table{}, err
+	}
+
+	return t, nil
+}
+
+// checkMatches reports an error if any result of the table's dice isn't matched by exactly one item
+func checkMatches(t roll.Table) error {
+	var (
+		min   = t.Dice.N * t.Dice.Die.Min().N
+		max   = t.Dice.N * t.Dice.Die.Max().N
+		count = make(map[int]int)
+	)
+
+	for _, item := range t.Items {
+		for _, n := range item.Match {
+			count[n]++
+		}
+	}
+
+	var missing, overlap, outside []int
+	for n := min; n <= max; n++ {
+		switch {
+		case count[n] == 0:
+			missing = append(missing, n)
+		case count[n] > 1:
+			overlap = append(overlap, n)
+		}
+	}
+
+	for n := range count {
+		if n < min || n > max {
+			outside = append(outside, n)
+		}
+	}
+	sort.Ints(outside)
+
+	var errs []string
+	if len(missing) > 0 {
+		errs = append(errs, "no item matches "+joinInts(missing))
+	}
+	if len(overlap) > 0 {
+		errs = append(errs, "more than one item matches "+joinInts(overlap))
+	}
+	if len(outside) > 0 {
+		errs = append(errs, fmt.Sprintf("%s can't be rolled on %dd%d", joinInts(outside), t.Dice.N, t.Dice.Die.Max().N))
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("table %s: %s", t.ID, strings.Join(errs, ", "))
+	}
+
+	return nil
+}
+
+// LoadDir reads table definitions from the YAML and JSON files in dir and adds them to the
+// Registry. A table with the same ID as one that is already registered replaces it in the
+// Registry, generators roll their own tables so this only changes what is rolled by ID. The IDs of
+// the loaded tables are returned, a missing directory is not an error.
+func LoadDir(dir string) ([]string, error) {
+	files, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, f := range files {
+		switch strings.ToLower(filepath.Ext(f.Name())) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		defs, err := readDefinitions(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return ids, err
+		}
+
+		for _, d := range defs {
+			t, err := d.Table()
+			if err != nil {
+				return ids, fmt.Errorf("%s: %s", f.Name(), err)
+			}
+
+			Registry.Remove(t.ID)
+			Registry.Add(t)
+			ids = append(ids, t.ID)
+		}
+	}
+
+	return ids, nil
+}
+
+// readDefinitions reads a file containing either a single table definition or a list of them.
+// JSON is valid YAML so both are read the same way.
+func readDefinitions(path string) ([]Definition, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var defs []Definition
+	if err := yaml.Unmarshal(b, &defs); err == nil {
+		return defs, nil
+	}
+
+	var d Definition
+	if err := yaml.Unmarshal(b, &d); err != nil {
+		return nil, fmt.Errorf("%s: %s", filepath.Base(path), err)
+	}
+
+	return []Definition{d}, nil
+}
+
+// joinInts returns ns as a comma separated list
+func joinInts(ns []int) string {
+	s := make([]string, len(ns))
+	for i, n := range ns {
+		s[i] = strconv.Itoa(n)
+	}
+
+	return strings.Join(s, ",")
+}
+
+// die returns a die with faces numbered 1 to n
+func die(n int) roll.Die {
+	var f roll.Faces
+	for i := 1; i <= n; i++ {
+		f = append(f, roll.Face{N: i, Value: strconv.Itoa(i)})
+	}
+
+	return roll.NewDie(f)
+}
+
+// parseDice parses dice in the form NdM, i.e 2d6
+func parseDice(s string) (roll.Dice, error) {
+	p := strings.Split(strings.ToLower(strings.TrimSpace(s)), "d")
+	if len(p) != 2 {
+		return roll.Dice{}, fmt.Errorf("invalid dice \"%s\", use the form 2d6", s)
+	}
+
+	n, err1 := strconv.Atoi(p[0])
+	m, err2 := strconv.Atoi(p[1])
+	if err1 != nil || err2 != nil || n < 1 || m < 1 {
+		return roll.Dice{}, fmt.Errorf("invalid dice \"%s\", use the form 2d6", s)
+	}
+
+	return roll.Dice{N: n, Die: die(m)}, nil
+}
+
+// parseMatch parses a number (2), a range (3-5) or a set of numbers (6,7,8)
+func parseMatch(s string) (roll.TableMatchSet, error) {
+	var m roll.TableMatchSet
+
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		if r := strings.SplitN(part, "-", 2); len(r) == 2 {
+			start, err1 := strconv.Atoi(strings.TrimSpace(r[0]))
+			end, err2 := strconv.Atoi(strings.TrimSpace(r[1]))
+			if err1 != nil || err2 != nil || end < start {
+				return nil, fmt.Errorf("invalid match \"%s\"", s)
+			}
+
+			m = append(m, roll.MatchRange(start, end)...)
+			continue
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid match \"%s\"", s)
+		}
+
+		m = append(m, n)
+	}
+
+	return m, nil
+}
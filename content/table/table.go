@@ -4,19 +4,14 @@ package table
 
 import (
 	"fmt"
-	"math/rand"
-	"time"
 
 	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/dice"
 )
 
 // Registry of tables
 var Registry = roll.NewTableRegistry()
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
-}
-
 // ThreePart represents a relatively common structure for multi-layer tables in
 // SWN (RE)
 type ThreePart struct {
@@ -24,14 +19,14 @@ type ThreePart struct {
 	Tables  []ThreePartSubTable
 }
 
-// Roll performs all rolls on a ThreePart table
-func (t ThreePart) Roll() [][]string {
-	i := rand.Intn(len(t.Tables))
+// Roll performs all rolls on a ThreePart table with rng
+func (t ThreePart) Roll(rng *dice.Rand) [][]string {
+	i := rng.Intn(len(t.Tables))
 
 	return [][]string{
 		{t.Headers[0], t.Tables[i].Name},
-		{t.Headers[1], t.Tables[i].SubTable1.Roll()},
-		{t.Headers[2], t.Tables[i].SubTable2.Roll()},
+		{t.Headers[1], rng.Roll(t.Tables[i].SubTable1)},
+		{t.Headers[2], rng.Roll(t.Tables[i].SubTable2)},
 	}
 }
 
@@ -66,15 +61,15 @@ type OneRoll struct {
 	D20 roll.Tabler
 }
 
-// Roll performs all rolls for a OneRoll and returns the results
-func (o OneRoll) Roll() [][]string {
+// Roll performs all rolls for a OneRoll with rng and returns the results
+func (o OneRoll) Roll(rng *dice.Rand) [][]string {
 	return [][]string{
-		{o.D4.Label(), o.D4.Roll()},
-		{o.D6.Label(), o.D6.Roll()},
-		{o.D8.Label(), o.D8.Roll()},
-		{o.D10.Label(), o.D10.Roll()},
-		{o.D12.Label(), o.D12.Roll()},
-		{o.D20.Label(), o.D20.Roll()},
+		{o.D4.Label(), rng.Roll(o.D4)},
+		{o.D6.Label(), rng.Roll(o.D6)},
+		{o.D8.Label(), rng.Roll(o.D8)},
+		{o.D10.Label(), rng.Roll(o.D10)},
+		{o.D12.Label(), rng.Roll(o.D12)},
+		{o.D20.Label(), rng.Roll(o.D20)},
 	}
 }
 
@@ -0,0 +1,120 @@
+package content
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nboughton/go-roll"
+	"gopkg.in/yaml.v2"
+)
+
+// Tags is the collection of world tags in use. It holds the built-in tags unless it has been
+// changed with UseTags.
+var Tags = append(TagsTable{}, builtinTags...)
+
+// tagDefinition is the file format of a tag in a tag pack
+type tagDefinition struct {
+	Name          string   `yaml:"name"`
+	Desc          string   `yaml:"desc"`
+	Enemies       []string `yaml:"enemies"`
+	Friends       []string `yaml:"friends"`
+	Complications []string `yaml:"complications"`
+	Things        []string `yaml:"things"`
+	Places        []string `yaml:"places"`
+}
+
+// LoadTagPack reads world tags from a YAML or JSON file, or from every such file in a directory.
+// Each file holds a list of tags with the fields name, desc, enemies, friends, complications,
+// things and places, where all but name and desc are lists of text.
+func LoadTagPack(path string) (TagsTable, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files = nil
+
+		entries, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range entries {
+			switch strings.ToLower(filepath.Ext(e.Name())) {
+			case ".yaml", ".yml", ".json":
+				files = append(files, filepath.Join(path, e.Name()))
+			}
+		}
+	}
+
+	var tags TagsTable
+	for _, f := range files {
+		b, err := ioutil.ReadFile(f)
+		if err != nil {
+			return tags, err
+		}
+
+		var defs []tagDefinition
+		if err := yaml.Unmarshal(b, &defs); err != nil { // JSON is valid YAML so both are read the same way
+			return tags, fmt.Errorf("%s: %s", filepath.Base(f), err)
+		}
+
+		for _, d := range defs {
+			if d.Name == "" {
+				return tags, fmt.Errorf("%s: tag has no name", filepath.Base(f))
+			}
+
+			tags = append(tags, Tag{
+				Name:          d.Name,
+				Desc:          d.Desc,
+				Enemies:       roll.List{Items: d.Enemies},
+				Friends:       roll.List{Items: d.Friends},
+				Complications: roll.List{Items: d.Complications},
+				Things:        roll.List{Items: d.Things},
+				Places:        roll.List{Items: d.Places},
+			})
+		}
+	}
+
+	return tags, nil
+}
+
+// UseTags sets the world tags in use to the built-in tags, unless builtin is false, merged with
+// packs. Tags in packs replace any existing tag of the same name.
+func UseTags(packs TagsTable, builtin bool) error {
+	var t TagsTable
+	if builtin {
+		t = append(t, builtinTags...)
+	}
+
+	for _, tag := range packs {
+		if i := t.index(tag.Name); i >= 0 {
+			t[i] = tag
+		} else {
+			t = append(t, tag)
+		}
+	}
+
+	if len(t) < 2 {
+		return fmt.Errorf("at least 2 world tags are needed, found %d", len(t))
+	}
+
+	Tags = t
+	return nil
+}
+
+// index returns the position of the tag called name or -1 if there is no such tag
+func (t TagsTable) index(name string) int {
+	for i, tag := range t {
+		if strings.ToLower(tag.Name) == strings.ToLower(name) {
+			return i
+		}
+	}
+
+	return -1
+}
@@ -3,26 +3,35 @@ package content
 import (
 	"bytes"
 	"fmt"
-	"math/rand"
 	"strings"
 
 	"github.com/nboughton/go-roll"
 	"github.com/nboughton/swnt/content/culture"
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/name"
+	"github.com/nboughton/swnt/content/table"
+	"github.com/nboughton/swnt/dice"
 )
 
+func init() {
+	table.Registry.Add(worldTable.atmosphere.Table)
+	table.Registry.Add(worldTable.biosphere.Table)
+	table.Registry.Add(worldTable.temperature.Table)
+	table.Registry.Add(worldTable.techLevel.Table)
+	table.Registry.Add(worldTable.population.Table)
+}
+
 // TagsTable represents the collection of Tags
 type TagsTable []Tag
 
 // Roll selects a random Tag
-func (t TagsTable) Roll() string {
-	return fmt.Sprint(t[rand.Intn(len(t))])
+func (t TagsTable) Roll(rng *dice.Rand) string {
+	return fmt.Sprint(t[rng.Intn(len(t))])
 }
 
 // Random selects a random tag (used in Adventure seed generation)
-func (t TagsTable) Random() string {
-	return Tags[rand.Intn(len(Tags))].Name
+func (t TagsTable) Random(rng *dice.Rand) string {
+	return Tags[rng.Intn(len(Tags))].Name
 }
 
 // Find returns the tag specified. The search is case insensitive for convenience
@@ -36,7 +45,8 @@ func (t TagsTable) Find(name string) (Tag, error) {
 	return Tag{}, fmt.Errorf("no tag with name \"%s\"", name)
 }
 
-func selectTags(exclude []string) (Tag, Tag) {
+// selectTags returns two different tags that aren't excluded, or an error if fewer than two are left
+func selectTags(rng *dice.Rand, exclude []string) (Tag, Tag, error) {
 	var t TagsTable
 	for _, tag := range Tags {
 		if !tag.match(exclude) {
@@ -44,12 +54,16 @@ func selectTags(exclude []string) (Tag, Tag) {
 		}
 	}
 
-	t1Idx, t2Idx := rand.Intn(len(t)), rand.Intn(len(t))
+	if len(t) < 2 { // Possible with small tag packs
+		return Tag{}, Tag{}, fmt.Errorf("excluding %s leaves fewer than 2 world tags to choose from", strings.Join(exclude, ", "))
+	}
+
+	t1Idx, t2Idx := rng.Intn(len(t)), rng.Intn(len(t))
 	for t1Idx == t2Idx { // Ensure the same tag isn't selected twice
-		t2Idx = rand.Intn(len(t))
+		t2Idx = rng.Intn(len(t))
 	}
 
-	return t[t1Idx], t[t2Idx]
+	return t[t1Idx], t[t2Idx], nil
 }
 
 func (t Tag) match(s []string) bool {
@@ -96,11 +110,11 @@ type World struct {
 	Name         string
 	Culture      culture.Culture
 	Tags         [2]Tag
-	Atmosphere   string
-	Temperature  string
-	Population   string
-	Biosphere    string
-	TechLevel    string
+	Atmosphere   Atmosphere
+	Temperature  Temperature
+	Population   Population
+	Biosphere    Biosphere
+	TechLevel    TechLevel
 	Origin       string
 	Relationship string
 	Contact      string
@@ -108,30 +122,33 @@ type World struct {
 
 // NewWorld creates a new world. Set culture to culture.Any for a random culture and primary to false
 // to include relationship information. If tagNamesOnly is true then format output will not include full
-// tag text
-func NewWorld(primary bool, c culture.Culture, fullTags bool, excludeTags []string) World {
-	t1, t2 := selectTags(excludeTags)
+// tag text. An error is returned if excludeTags leaves fewer than 2 tags to choose from.
+func NewWorld(rng *dice.Rand, primary bool, c culture.Culture, fullTags bool, excludeTags []string) (World, error) {
+	t1, t2, err := selectTags(rng, excludeTags)
+	if err != nil {
+		return World{}, err
+	}
 
 	w := World{
 		Primary:     primary,
 		FullTags:    fullTags,
-		Name:        name.Table.ByCulture(c).Place.Roll(),
+		Name:        rng.Roll(name.Table.ByCulture(rng, c).Place),
 		Culture:     c,
 		Tags:        [2]Tag{t1, t2},
-		Atmosphere:  worldTable.atmosphere.Roll(),
-		Temperature: worldTable.temperature.Roll(),
-		Population:  worldTable.population.Roll(),
-		Biosphere:   worldTable.biosphere.Roll(),
-		TechLevel:   worldTable.techLevel.Roll(),
+		Atmosphere:  Atmosphere{worldTable.atmosphere.roll(rng)},
+		Temperature: Temperature{worldTable.temperature.roll(rng)},
+		Population:  Population{worldTable.population.roll(rng)},
+		Biosphere:   Biosphere{worldTable.biosphere.roll(rng)},
+		TechLevel:   TechLevel{worldTable.techLevel.roll(rng)},
 	}
 
 	if !w.Primary {
-		w.Origin = otherWorldTable.origin.Roll()
-		w.Relationship = otherWorldTable.relationship.Roll()
-		w.Contact = otherWorldTable.contact.Roll()
+		w.Origin = rng.Roll(otherWorldTable.origin)
+		w.Relationship = rng.Roll(otherWorldTable.relationship)
+		w.Contact = rng.Roll(otherWorldTable.contact)
 	}
 
-	return w
+	return w, nil
 }
 
 // Format returns the content of World w in format t
@@ -139,12 +156,12 @@ func (w World) Format(t format.OutputType) string {
 	var buf = new(bytes.Buffer)
 
 	fmt.Fprintf(buf, format.Table(t, []string{w.Name, ""}, [][]string{
-		{"Atmosphere", w.Atmosphere},
-		{"Temperature", w.Temperature},
-		{"Biosphere", w.Biosphere},
-		{"Population", w.Population},
+		{"Atmosphere", w.Atmosphere.Desc},
+		{"Temperature", w.Temperature.Desc},
+		{"Biosphere", w.Biosphere.Desc},
+		{"Population", w.Population.Desc},
 		{"Culture", w.Culture.String()},
-		{"Tech Level", w.TechLevel},
+		{"Tech Level", w.TechLevel.Desc},
 	}))
 
 	if !w.FullTags {
@@ -223,85 +240,110 @@ var otherWorldTable = struct {
 }
 
 var worldTable = struct {
-	atmosphere  roll.Table
-	biosphere   roll.Table
-	temperature roll.Table
-	techLevel   roll.Table
-	population  roll.Table
+	atmosphere  attributeTable
+	biosphere   attributeTable
+	temperature attributeTable
+	techLevel   attributeTable
+	population  attributeTable
 }{
 	// Atmosphere List
-	roll.Table{
-		Dice: roll.Dice{N: 2, Die: roll.D6},
-		Items: []roll.TableItem{
-			{Match: []int{2}, Text: "Corrosive, damaging to foreign objects"},
-			{Match: []int{3}, Text: "Inert gas, useless for respiration"},
-			{Match: []int{4}, Text: "Airless or thin to the point of suffocation"},
-			{Match: []int{5, 6, 7, 8, 9}, Text: "Breathable mix"},
-			{Match: []int{10}, Text: "Thick, but breathable with a pressure mask"},
-			{Match: []int{11}, Text: "Invasive, penetrating suit seals"},
-			{Match: []int{12}, Text: "Both corrosive and invasive in its effect"},
-		},
+	attributeTable{
+		roll.Table{
+			ID:   "world.Atmosphere",
+			Name: "Atmosphere",
+			Dice: roll.Dice{N: 2, Die: roll.D6},
+			Items: []roll.TableItem{
+				{Match: []int{2}, Text: "Corrosive, damaging to foreign objects"},
+				{Match: []int{3}, Text: "Inert gas, useless for respiration"},
+				{Match: []int{4}, Text: "Airless or thin to the point of suffocation"},
+				{Match: []int{5, 6, 7, 8, 9}, Text: "Breathable mix"},
+				{Match: []int{10}, Text: "Thick, but breathable with a pressure mask"},
+				{Match: []int{11}, Text: "Invasive, penetrating suit seals"},
+				{Match: []int{12}, Text: "Both corrosive and invasive in its effect"},
+			},
+		},
+		[]string{"corrosive", "inert", "airless", "breathable", "thick", "invasive", "corrosiveInvasive"},
 	},
 
 	// Biosphere List
-	roll.Table{
-		Dice: roll.Dice{N: 2, Die: roll.D6},
-		Items: []roll.TableItem{
-			{Match: []int{2}, Text: "Remnant biosphere"},
-			{Match: []int{3}, Text: "Microbial life forms exist"},
-			{Match: []int{4, 5}, Text: "No native biosphere"},
-			{Match: []int{6, 7, 8}, Text: "Human-miscible biosphere"},
-			{Match: []int{9, 10}, Text: "Immiscible biosphere"},
-			{Match: []int{11}, Text: "Hybrid biosphere"},
-			{Match: []int{12}, Text: "Engineered biosphere"},
-		},
+	attributeTable{
+		roll.Table{
+			ID:   "world.Biosphere",
+			Name: "Biosphere",
+			Dice: roll.Dice{N: 2, Die: roll.D6},
+			Items: []roll.TableItem{
+				{Match: []int{2}, Text: "Remnant biosphere"},
+				{Match: []int{3}, Text: "Microbial life forms exist"},
+				{Match: []int{4, 5}, Text: "No native biosphere"},
+				{Match: []int{6, 7, 8}, Text: "Human-miscible biosphere"},
+				{Match: []int{9, 10}, Text: "Immiscible biosphere"},
+				{Match: []int{11}, Text: "Hybrid biosphere"},
+				{Match: []int{12}, Text: "Engineered biosphere"},
+			},
+		},
+		[]string{"remnant", "microbial", "noBiosphere", "humanMiscible", "immiscible", "hybrid", "engineered"},
 	},
 
 	// Temperature List
-	roll.Table{
-		Dice: roll.Dice{N: 2, Die: roll.D6},
-		Items: []roll.TableItem{
-			{Match: []int{2}, Text: "Frozen, locked in perpetual ice"},
-			{Match: []int{3}, Text: "Cold, dominated by glaciers and tundra"},
-			{Match: []int{4, 5}, Text: "Variable cold with temperate places"},
-			{Match: []int{6, 7, 8}, Text: "Temperate, Earthlike in its ranges"},
-			{Match: []int{9, 10}, Text: "Variable warm, with temperate places"},
-			{Match: []int{11}, Text: "Warm, tropical and hotter in places"},
-			{Match: []int{12}, Text: "Burning, intolerably hot on its surface"},
-		},
+	attributeTable{
+		roll.Table{
+			ID:   "world.Temperature",
+			Name: "Temperature",
+			Dice: roll.Dice{N: 2, Die: roll.D6},
+			Items: []roll.TableItem{
+				{Match: []int{2}, Text: "Frozen, locked in perpetual ice"},
+				{Match: []int{3}, Text: "Cold, dominated by glaciers and tundra"},
+				{Match: []int{4, 5}, Text: "Variable cold with temperate places"},
+				{Match: []int{6, 7, 8}, Text: "Temperate, Earthlike in its ranges"},
+				{Match: []int{9, 10}, Text: "Variable warm, with temperate places"},
+				{Match: []int{11}, Text: "Warm, tropical and hotter in places"},
+				{Match: []int{12}, Text: "Burning, intolerably hot on its surface"},
+			},
+		},
+		[]string{"frozen", "cold", "variableCold", "temperate", "variableWarm", "warm", "burning"},
 	},
 
 	// TechLevel List
-	roll.Table{
-		Dice: roll.Dice{N: 2, Die: roll.D6},
-		Items: []roll.TableItem{
-			{Match: []int{2}, Text: "TL0, neolithic-level technology"},
-			{Match: []int{3}, Text: "TL1, medieval technology"},
-			{Match: []int{4, 5}, Text: "TL2, early Industrial Age tech"},
-			{Match: []int{6, 7, 8}, Text: "TL4, modern postech"},
-			{Match: []int{9, 10}, Text: "TL3, tech like that of present-day Earth"},
-			{Match: []int{11}, Text: "TL4+, postech with specialties"},
-			{Match: []int{12}, Text: "TL5, pretech with surviving infrastructure"},
-		},
+	attributeTable{
+		roll.Table{
+			ID:   "world.TechLevel",
+			Name: "Tech Level",
+			Dice: roll.Dice{N: 2, Die: roll.D6},
+			Items: []roll.TableItem{
+				{Match: []int{2}, Text: "TL0, neolithic-level technology"},
+				{Match: []int{3}, Text: "TL1, medieval technology"},
+				{Match: []int{4, 5}, Text: "TL2, early Industrial Age tech"},
+				{Match: []int{6, 7, 8}, Text: "TL4, modern postech"},
+				{Match: []int{9, 10}, Text: "TL3, tech like that of present-day Earth"},
+				{Match: []int{11}, Text: "TL4+, postech with specialties"},
+				{Match: []int{12}, Text: "TL5, pretech with surviving infrastructure"},
+			},
+		},
+		[]string{"TL0", "TL1", "TL2", "TL4", "TL3", "TL4+", "TL5"},
 	},
 
 	// Population List
-	roll.Table{
-		Dice: roll.Dice{N: 2, Die: roll.D6},
-		Items: []roll.TableItem{
-			{Match: []int{2}, Text: "Failed colony"},
-			{Match: []int{3}, Text: "Outpost"},
-			{Match: []int{4, 5}, Text: "Fewer than a million inhabitants"},
-			{Match: []int{6, 7, 8}, Text: "Several million inhabitants"},
-			{Match: []int{9, 10}, Text: "Hundreds of millions of inhabitants"},
-			{Match: []int{11}, Text: "Billions of inhabitants"},
-			{Match: []int{12}, Text: "Alien inhabitants"},
-		},
+	attributeTable{
+		roll.Table{
+			ID:   "world.Population",
+			Name: "Population",
+			Dice: roll.Dice{N: 2, Die: roll.D6},
+			Items: []roll.TableItem{
+				{Match: []int{2}, Text: "Failed colony"},
+				{Match: []int{3}, Text: "Outpost"},
+				{Match: []int{4, 5}, Text: "Fewer than a million inhabitants"},
+				{Match: []int{6, 7, 8}, Text: "Several million inhabitants"},
+				{Match: []int{9, 10}, Text: "Hundreds of millions of inhabitants"},
+				{Match: []int{11}, Text: "Billions of inhabitants"},
+				{Match: []int{12}, Text: "Alien inhabitants"},
+			},
+		},
+		[]string{"failedColony", "outpost", "fewerThanMillion", "severalMillion", "hundredsOfMillions", "billions", "alien"},
 	},
 }
 
-// Tags List
-var Tags = TagsTable{
+// builtinTags are the world tags from Stars Without Number (Revised Edition)
+var builtinTags = TagsTable{
 	{
 		Name: "Abandoned Colony",
 		Desc: "The world once hosted a colony, whether human or otherwise, until some crisis or natural disaster drove the inhabitants away or killed them off. The colony might have been mercantile in nature, an expedition to extract valuable local resources, or it might have been a reclusive cabal of zealots. The remains of the colony are usually in ruins, and might still be dangerous from the aftermath of whatever destroyed it in the first place.",
@@ -0,0 +1,158 @@
+package content
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/nboughton/go-roll"
+	"github.com/nboughton/swnt/dice"
+)
+
+// Attribute is a World attribute rolled on one of the world tables. Roll is the 2d6 result it was
+// rolled with, Code a short identifier for the result (i.e "breathable" or "TL4+") and Desc the
+// text of the table item. Attributes that weren't rolled, such as those of older sectors or
+// imported worlds, have a Roll of 0. Values that aren't from the world tables keep their text as
+// both Code and Desc.
+type Attribute struct {
+	Roll int
+	Code string
+	Desc string
+}
+
+func (a Attribute) String() string {
+	return a.Desc
+}
+
+// Atmosphere of a World
+type Atmosphere struct{ Attribute }
+
+// Temperature of a World
+type Temperature struct{ Attribute }
+
+// Biosphere of a World
+type Biosphere struct{ Attribute }
+
+// Population of a World
+type Population struct{ Attribute }
+
+// TechLevel of a World, its Code is the short form i.e "TL4+"
+type TechLevel struct{ Attribute }
+
+// ParseAtmosphere returns the Atmosphere with the code or table text s
+func ParseAtmosphere(s string) Atmosphere {
+	return Atmosphere{worldTable.atmosphere.parse(s)}
+}
+
+// ParseTemperature returns the Temperature with the code or table text s
+func ParseTemperature(s string) Temperature {
+	return Temperature{worldTable.temperature.parse(s)}
+}
+
+// ParseBiosphere returns the Biosphere with the code or table text s
+func ParseBiosphere(s string) Biosphere {
+	return Biosphere{worldTable.biosphere.parse(s)}
+}
+
+// ParsePopulation returns the Population with the code or table text s
+func ParsePopulation(s string) Population {
+	return Population{worldTable.population.parse(s)}
+}
+
+// ParseTechLevel returns the TechLevel with the code or table text s
+func ParseTechLevel(s string) TechLevel {
+	return TechLevel{worldTable.techLevel.parse(s)}
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler, older sectors stored the table text as a string
+func (a *Atmosphere) UnmarshalJSON(b []byte) error {
+	return a.decode(b, worldTable.atmosphere)
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler, older sectors stored the table text as a string
+func (t *Temperature) UnmarshalJSON(b []byte) error {
+	return t.decode(b, worldTable.temperature)
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler, older sectors stored the table text as a string
+func (bs *Biosphere) UnmarshalJSON(b []byte) error {
+	return bs.decode(b, worldTable.biosphere)
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler, older sectors stored the table text as a string
+func (p *Population) UnmarshalJSON(b []byte) error {
+	return p.decode(b, worldTable.population)
+}
+
+// UnmarshalJSON satisfies json.Unmarshaler, older sectors stored the table text as a string
+func (t *TechLevel) UnmarshalJSON(b []byte) error {
+	return t.decode(b, worldTable.techLevel)
+}
+
+// decode reads a from either an Attribute object or the table text of t
+func (a *Attribute) decode(b []byte, t attributeTable) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = t.parse(s)
+		return nil
+	}
+
+	type attribute Attribute // Drops the methods of Attribute so that decoding doesn't recurse
+	return json.Unmarshal(b, (*attribute)(a))
+}
+
+// AttributeValues lists the values of each World attribute
+type AttributeValues struct {
+	Atmosphere, Temperature, Biosphere, Population, TechLevel []Attribute
+}
+
+// WorldAttributeValues returns every value of each World attribute, in the order of the world
+// tables
+func WorldAttributeValues() AttributeValues {
+	return AttributeValues{
+		Atmosphere:  worldTable.atmosphere.values(),
+		Temperature: worldTable.temperature.values(),
+		Biosphere:   worldTable.biosphere.values(),
+		Population:  worldTable.population.values(),
+		TechLevel:   worldTable.techLevel.values(),
+	}
+}
+
+// attributeTable is a world table with a Code for each of its Items
+type attributeTable struct {
+	roll.Table
+	Codes []string
+}
+
+// roll returns the Attribute rolled on t. It rolls the same dice as Table.Roll so that seeded
+// sectors are unchanged.
+func (t attributeTable) roll(rng *dice.Rand) Attribute {
+	n := rng.Sum(t.Dice)
+	for i, item := range t.Items {
+		if item.Match.Contains(n) {
+			return Attribute{Roll: n, Code: t.Codes[i], Desc: item.Text}
+		}
+	}
+
+	return Attribute{Roll: n}
+}
+
+// parse returns the Attribute of t with the code or text s, ignoring case
+func (t attributeTable) parse(s string) Attribute {
+	for _, a := range t.values() {
+		if strings.EqualFold(a.Code, s) || strings.EqualFold(a.Desc, s) {
+			return a
+		}
+	}
+
+	return Attribute{Code: s, Desc: s}
+}
+
+// values returns the Attributes of t in table order
+func (t attributeTable) values() []Attribute {
+	var out []Attribute
+	for i, item := range t.Items {
+		out = append(out, Attribute{Code: t.Codes[i], Desc: item.Text})
+	}
+
+	return out
+}
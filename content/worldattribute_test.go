@@ -0,0 +1,57 @@
+package content
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// legacyWorld is a World as it was saved before attributes had codes, with the table text of each
+// attribute stored as a string
+const legacyWorld = `{
+	"Primary": true,
+	"FullTags": false,
+	"Name": "Ankleshwar",
+	"Culture": "Indian",
+	"Atmosphere": "Breathable mix",
+	"Temperature": "Variable cold with temperate places",
+	"Population": "Several million inhabitants",
+	"Biosphere": "Human-miscible biosphere",
+	"TechLevel": "TL4, modern postech",
+	"Origin": "",
+	"Relationship": "",
+	"Contact": ""
+}`
+
+func TestDecodeLegacyWorld(t *testing.T) {
+	var w World
+	if err := json.Unmarshal([]byte(legacyWorld), &w); err != nil {
+		t.Fatalf("decoding legacy world: %s", err)
+	}
+
+	for _, tc := range []struct {
+		name       string
+		got        Attribute
+		code, desc string
+	}{
+		{"Atmosphere", w.Atmosphere.Attribute, "breathable", "Breathable mix"},
+		{"Temperature", w.Temperature.Attribute, "variableCold", "Variable cold with temperate places"},
+		{"Population", w.Population.Attribute, "severalMillion", "Several million inhabitants"},
+		{"Biosphere", w.Biosphere.Attribute, "humanMiscible", "Human-miscible biosphere"},
+		{"TechLevel", w.TechLevel.Attribute, "TL4", "TL4, modern postech"},
+	} {
+		if tc.got.Code != tc.code || tc.got.Desc != tc.desc || tc.got.Roll != 0 {
+			t.Errorf("%s = %+v, want Code %q, Desc %q and Roll 0", tc.name, tc.got, tc.code, tc.desc)
+		}
+	}
+}
+
+func TestDecodeUnknownAttribute(t *testing.T) {
+	var a Atmosphere
+	if err := json.Unmarshal([]byte(`"Thin and smoky"`), &a); err != nil {
+		t.Fatalf("decoding attribute: %s", err)
+	}
+
+	if a.Code != "Thin and smoky" || a.Desc != "Thin and smoky" {
+		t.Errorf("Atmosphere = %+v, want the text as both Code and Desc", a.Attribute)
+	}
+}
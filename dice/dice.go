@@ -1,6 +1,172 @@
+// Package dice provides the custom dice used in SWN tables and the random source that every table
+// and generator in swnt draws from
 package dice
 
-import "github.com/nboughton/go-roll"
+import (
+	"math/rand"
+
+	"github.com/nboughton/go-roll"
+)
 
 // D5 used in a few roll tables
 var D5 = roll.NewDie(roll.Faces{{N: 1, Value: "1"}, {N: 2, Value: "2"}, {N: 3, Value: "3"}, {N: 4, Value: "4"}, {N: 5, Value: "5"}})
+
+// Rand is a seeded random source. Generators are given a Rand to draw from rather than using the
+// math/rand global source, so content can be reproduced from the seed it was generated with and
+// separate sources (i.e one for each serve request) don't disturb each other.
+//
+// go-roll always rolls with the global source so tables are rolled with the Rand's List, Table and
+// Roll methods rather than their own Roll methods.
+type Rand struct {
+	*rand.Rand
+	seed int64
+}
+
+// New returns a Rand seeded with seed
+func New(seed int64) *Rand {
+	return &Rand{Rand: rand.New(rand.NewSource(seed)), seed: seed}
+}
+
+// Seed seeds r with n
+func (r *Rand) Seed(n int64) {
+	r.seed = n
+	r.Rand.Seed(n)
+}
+
+// CurrentSeed returns the value r was last seeded with
+func (r *Rand) CurrentSeed() int64 {
+	return r.seed
+}
+
+// Reseed seeds r with a new value drawn from r and returns it. This allows successive results (such
+// as rerolled sectors) to each be reproduced from a single seed.
+func (r *Rand) Reseed() int64 {
+	n := r.Int63()
+	r.Seed(n)
+	return n
+}
+
+// Die returns the result of rolling d. The dice used by swnt all have faces numbered from their
+// lowest to their highest value.
+func (r *Rand) Die(d roll.Die) int {
+	min, max := d.Min().N, d.Max().N
+	return min + r.Intn(max-min+1)
+}
+
+// Sum returns the total of rolling d
+func (r *Rand) Sum(d roll.Dice) int {
+	n := 0
+	for i := 0; i < d.N; i++ {
+		n += r.Die(d.Die)
+	}
+
+	return n
+}
+
+// List returns a random item of l
+func (r *Rand) List(l roll.List) string {
+	if len(l.Items) > 0 {
+		return l.Items[r.Intn(len(l.Items))]
+	}
+
+	return ""
+}
+
+// Table rolls on t and returns the item drawn, as roll.Table.Roll does. Items that roll on other
+// tables have an Action (see AddAction), the Action fields of roll.TableItems are not used as they
+// can't be given a random source.
+func (r *Rand) Table(t roll.Table) string {
+	var (
+		out string
+		n   = r.clamp(t.Dice, r.Sum(t.Dice)+t.Mod)
+	)
+
+	// Record initial roll result
+	for _, i := range t.Items {
+		if i.Match.Contains(n) {
+			out = i.Text
+		}
+	}
+
+	// Check for a reroll
+	if t.Reroll.Match.Contains(n) {
+		n = r.Sum(t.Reroll.Dice)
+		for _, i := range t.Items {
+			if i.Match.Contains(n) {
+				out = join(out, i.Text)
+			}
+		}
+	}
+
+	// Append text for final roll result
+	for _, i := range t.Items {
+		if i.Match.Contains(n) {
+			if a, ok := actions[t.ID][i.Text]; ok && t.ID != "" {
+				out = join(out, a(r))
+			}
+
+			return out
+		}
+	}
+
+	return ""
+}
+
+// Roll returns the result of rolling on t. Tablers other than Lists and Tables must implement
+// Tabler, any that don't are rolled with their own Roll method.
+func (r *Rand) Roll(t roll.Tabler) string {
+	switch t := t.(type) {
+	case Tabler:
+		return t.RollWith(r)
+	case roll.List:
+		return r.List(t)
+	case roll.Table:
+		return r.Table(t)
+	}
+
+	return t.Roll()
+}
+
+// Tabler is a roll.Tabler that can be rolled with a Rand
+type Tabler interface {
+	roll.Tabler
+	RollWith(r *Rand) string
+}
+
+// clamp limits n to the range of d
+func (r *Rand) clamp(d roll.Dice, n int) int {
+	if n < d.Min() {
+		return d.Min()
+	}
+
+	if n > d.Max() {
+		return d.Max()
+	}
+
+	return n
+}
+
+// join appends s to out, separated as roll.Table.Roll separates results
+func join(out, s string) string {
+	if out != "" {
+		out += "; "
+	}
+
+	return out + s
+}
+
+// Action is the result of a table item that rolls on other tables, drawn from r
+type Action func(r *Rand) string
+
+// actions by table ID and item text
+var actions = make(map[string]map[string]Action)
+
+// AddAction sets the Action of the item with text in the table with id. Actions are applied by
+// Rand.Table in place of roll.TableItem.Action.
+func AddAction(id, text string, a Action) {
+	if actions[id] == nil {
+		actions[id] = make(map[string]Action)
+	}
+
+	actions[id][text] = a
+}
@@ -4,10 +4,10 @@ package export
 import (
 	"fmt"
 	"os"
-	"strings"
 
 	"github.com/nboughton/swnt/content/sector"
 	"github.com/nboughton/swnt/haxscii"
+	"github.com/nboughton/swnt/hexmap"
 )
 
 var (
@@ -15,12 +15,15 @@ var (
 	filePerm = os.FileMode(0644)
 )
 
+// MapPixelSize sets the radius, in pixels, of the hexes in raster (PNG) maps
+var MapPixelSize = 60
+
 // Exporter represents any type that can Setup an export directory and output data to it.
 type Exporter interface {
 	Write() error
 }
 
-// New returns a new Exporter. Export types currently supported are: hugo, txt and json
+// New returns a new Exporter. Export types currently supported are: hugo, txt, json, html, obsidian, swn, sec and foundry
 func New(exportType, name string, data *sector.Stars) (Exporter, error) {
 	switch exportType {
 	case "hugo":
@@ -39,38 +42,105 @@ func New(exportType, name string, data *sector.Stars) (Exporter, error) {
 			Name:  name,
 			Stars: data,
 		}, nil
+
+	case "html":
+		return &HTML{
+			Name:  name,
+			Stars: data,
+		}, nil
+
+	case "obsidian":
+		return &Obsidian{
+			Name:  name,
+			Stars: data,
+		}, nil
+
+	case "swn":
+		return &SWN{
+			Name:  name,
+			Stars: data,
+		}, nil
+
+	case "sec":
+		return &SEC{
+			Name:  name,
+			Stars: data,
+		}, nil
+
+	case "foundry":
+		return &Foundry{
+			Name:  name,
+			Stars: data,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no Exporter found for [%s], available options are [%s]", exportType, []string{"hugo", "txt", "json", "html", "obsidian", "swn", "sec", "foundry"})
+}
+
+// exportDirs are the directories written by export types that write more than a single file
+var exportDirs = map[string]string{
+	"txt":      "text",
+	"hugo":     "hugo",
+	"obsidian": "obsidian",
+	"foundry":  "foundry",
+}
+
+// Clean removes the directory written by a previous export of exportType from the current
+// directory so that it can be exported again. Export types that write single files overwrite them
+// and have nothing to clean.
+func Clean(exportType string) error {
+	if dir, ok := exportDirs[exportType]; ok {
+		return os.RemoveAll(dir)
 	}
 
-	return nil, fmt.Errorf("no Exporter found for [%s], available options are [%s]", exportType, []string{"hugo", "txt", "json"})
+	return nil
+}
+
+// asciiColours maps the colours of image maps to those of p
+func asciiColours(p haxscii.Palette) map[hexmap.Colour]func(string, ...interface{}) string {
+	return map[hexmap.Colour]func(string, ...interface{}) string{
+		hexmap.White:   p.White,
+		hexmap.Red:     p.Red,
+		hexmap.Yellow:  p.Yellow,
+		hexmap.Magenta: p.Magenta,
+		hexmap.Green:   p.Green,
+		hexmap.Blue:    p.Blue,
+		hexmap.Cyan:    p.Cyan,
+	}
 }
 
 // Hexmap returns the ASCII representation of a Sector map
 func Hexmap(data *sector.Stars, useColour bool, playerMap bool) string {
-	haxscii.Colour(useColour)
-	h := haxscii.NewMap(data.Rows, data.Cols)
-	for _, s := range data.Systems {
-		name, tag1, tag2, tl := s.Name, s.Worlds[0].Tags[0].Name, s.Worlds[0].Tags[1].Name, strings.Split(s.Worlds[0].TechLevel, ",")[0]
-		c := haxscii.White // I default to black/dark terminals, this might be problematic for weirdos that use light terms
-
-		switch tl {
-		case "TL0":
-			c = haxscii.White
-		case "TL1":
-			c = haxscii.Red
-		case "TL2":
-			c = haxscii.Yellow
-		case "TL3":
-			c = haxscii.Magenta
-		case "TL4", "TL4+":
-			c = haxscii.Green
-		case "TL5":
-			c = haxscii.Cyan
+	var (
+		h       = haxscii.NewMap(data.Rows, data.Cols)
+		p       = haxscii.NewPalette(useColour)
+		colours = asciiColours(p)
+	)
+
+	// Lanes are drawn first so that system text is written over them
+	if !playerMap {
+		for _, l := range data.Lanes {
+			from, err1 := data.Find(l.From)
+			to, err2 := data.Find(l.To)
+			if err1 != nil || err2 != nil {
+				continue
+			}
+
+			h.Line(from.Row, from.Col, to.Row, to.Col, p.Blue)
 		}
+	}
+
+	for _, s := range data.Systems {
+		name, tag1, tag2, tl := s.Name, s.Worlds[0].Tags[0].Name, s.Worlds[0].Tags[1].Name, s.Worlds[0].TechLevel.Code
+		c := colours[hexmap.TLColour(tl)] // White is for dark terminals, this might be problematic for weirdos that use light terms
 
 		if playerMap {
 			h.SetTxt(s.Row, s.Col, [4]string{name, "", "", ""}, c)
 		} else {
 			h.SetTxt(s.Row, s.Col, [4]string{name, tag1, tag2, tl}, c)
+			if m := data.FactionMarker(s); m != "" {
+				h.SetMarker(s.Row, s.Col, m, p.Red)
+			}
 		}
 	}
 
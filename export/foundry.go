@@ -0,0 +1,237 @@
+package export
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/nboughton/go-utils/json/file"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/hexmap"
+)
+
+// Foundry represents the Exporter for Foundry VTT. It writes a JournalEntry for each Star, World and
+// POI and a Scene of the sector with a note pinned to each system that opens its journal entry.
+// Each document is written to a file of its own, which can be loaded with Foundry's Import Data
+// option, and all of them are collected in a single bundle for importing with a macro or module.
+// The GM map is written alongside as the background of the Scene, it must be uploaded to Foundry
+// and the Scene's background set to it once imported.
+type Foundry struct {
+	Name  string
+	Stars *sector.Stars
+}
+
+// Foundry constants used by the export
+const (
+	foundryMarkdown   = 2 // JOURNAL_ENTRY_PAGE_FORMATS.MARKDOWN
+	foundryHexOddQ    = 4 // GRID_TYPES.HEXODDQ, flat topped hexes with odd columns shifted down
+	foundryNoteIcon   = "icons/svg/sun.svg"
+	foundryBackground = "gm-map.png"
+)
+
+// foundryBundle holds every document of the export
+type foundryBundle struct {
+	Name    string                `json:"name"`
+	Journal []foundryJournalEntry `json:"journal"`
+	Scenes  []foundryScene        `json:"scenes"`
+}
+
+// foundryJournalEntry is a JournalEntry with a single text page
+type foundryJournalEntry struct {
+	ID    string               `json:"_id"`
+	Name  string               `json:"name"`
+	Pages []foundryJournalPage `json:"pages"`
+}
+
+// foundryJournalPage is a text JournalEntryPage. Content is the HTML shown in Foundry and Markdown
+// the source it is edited as.
+type foundryJournalPage struct {
+	ID   string `json:"_id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Text struct {
+		Format   int    `json:"format"`
+		Content  string `json:"content"`
+		Markdown string `json:"markdown"`
+	} `json:"text"`
+}
+
+// foundryScene is a Scene of the sector map
+type foundryScene struct {
+	ID         string `json:"_id"`
+	Name       string `json:"name"`
+	Navigation bool   `json:"navigation"`
+	Width      int    `json:"width"`
+	Height     int    `json:"height"`
+	Padding    int    `json:"padding"`
+	Background struct {
+		Src string `json:"src"`
+	} `json:"background"`
+	Grid struct {
+		Type     int     `json:"type"`
+		Size     int     `json:"size"`
+		Color    string  `json:"color"`
+		Alpha    float64 `json:"alpha"`
+		Distance int     `json:"distance"`
+		Units    string  `json:"units"`
+	} `json:"grid"`
+	Notes []foundryNote `json:"notes"`
+}
+
+// foundryNote is a map note pinned to a system's hex
+type foundryNote struct {
+	ID      string `json:"_id"`
+	EntryID string `json:"entryId"`
+	X       int    `json:"x"`
+	Y       int    `json:"y"`
+	Texture struct {
+		Src string `json:"src"`
+	} `json:"texture"`
+	IconSize int    `json:"iconSize"`
+	Text     string `json:"text"`
+	FontSize int    `json:"fontSize"`
+}
+
+// Write satisfies the Exporter interface
+func (f *Foundry) Write() error {
+	fmt.Println("Exporting as foundry vtt bundle...")
+
+	var (
+		root   = "foundry"
+		bundle = foundryBundle{Name: f.Name}
+		notes  []foundryNote
+	)
+
+	for _, dir := range []string{"journal", "scenes"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), dirPerm); err != nil {
+			return err
+		}
+	}
+
+	for _, s := range f.Stars.Systems {
+		var (
+			starEntry = foundryEntry(s.Name, f.Name, s.Name)
+			system    = "System: " + foundryLink(starEntry)
+			links     []string
+		)
+
+		for i, w := range s.Worlds {
+			e := foundryEntry(w.Name, f.Name, s.Name, "world", fmt.Sprint(i))
+			e.setPage(w.Format(format.MARKDOWN)+"\n"+system+"\n", w.Format(format.HTML)+"<p>"+system+"</p>\n")
+			bundle.Journal = append(bundle.Journal, e)
+			links = append(links, foundryLink(e))
+		}
+
+		for i, p := range s.POIs {
+			e := foundryEntry(s.Name+" "+p.Point, f.Name, s.Name, "poi", fmt.Sprint(i))
+			e.setPage(p.Format(format.MARKDOWN)+"\n"+system+"\n", p.Format(format.HTML)+"<p>"+system+"</p>\n")
+			bundle.Journal = append(bundle.Journal, e)
+			links = append(links, foundryLink(e))
+		}
+
+		entries := "Journal: " + strings.Join(links, ", ")
+		starEntry.setPage(s.Format(format.MARKDOWN)+"\n"+entries+"\n", s.Format(format.HTML)+"<p>"+entries+"</p>\n")
+		bundle.Journal = append(bundle.Journal, starEntry)
+
+		x, y := hexmap.Centre(s.Row, s.Col, MapPixelSize)
+		n := foundryNote{
+			ID:       foundryID(f.Name, s.Name, "note"),
+			EntryID:  starEntry.ID,
+			X:        int(math.Round(x)),
+			Y:        int(math.Round(y)),
+			IconSize: MapPixelSize / 2,
+			Text:     s.Name,
+			FontSize: 24,
+		}
+		n.Texture.Src = foundryNoteIcon
+		notes = append(notes, n)
+	}
+
+	w, h := hexmap.Size(f.Stars.Rows, f.Stars.Cols, MapPixelSize)
+	scene := foundryScene{
+		ID:         foundryID(f.Name, "scene"),
+		Name:       f.Name,
+		Navigation: true,
+		Width:      int(math.Ceil(w)) + 1,
+		Height:     int(math.Ceil(h)) + 1,
+		Notes:      notes,
+	}
+	scene.Background.Src = foundryBackground
+	scene.Grid.Type = foundryHexOddQ
+	scene.Grid.Size = int(math.Round(math.Sqrt(3) * float64(MapPixelSize))) // Flat side to flat side
+	scene.Grid.Color = "#505050"
+	scene.Grid.Alpha = 0.2
+	scene.Grid.Distance = 1
+	scene.Grid.Units = "hex"
+	bundle.Scenes = append(bundle.Scenes, scene)
+
+	for _, e := range bundle.Journal {
+		if err := file.Write(filepath.Join(root, "journal", foundryFileName(e.Name, e.ID)), e); err != nil {
+			return err
+		}
+	}
+
+	if err := file.Write(filepath.Join(root, "scenes", foundryFileName(scene.Name, scene.ID)), scene); err != nil {
+		return err
+	}
+
+	img, err := hexmap.PNG(f.Stars, false, MapPixelSize)
+	if err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(root, foundryBackground), img, filePerm); err != nil {
+		return err
+	}
+
+	return file.Write(filepath.Join(root, f.Name+".json"), bundle)
+}
+
+// foundryEntry returns an empty JournalEntry called name, with an ID derived from keys
+func foundryEntry(name string, keys ...string) foundryJournalEntry {
+	return foundryJournalEntry{ID: foundryID(append(keys, "entry")...), Name: name}
+}
+
+// setPage sets the single page of the entry
+func (e *foundryJournalEntry) setPage(markdown, html string) {
+	p := foundryJournalPage{ID: foundryID(e.ID, "page"), Name: e.Name, Type: "text"}
+	p.Text.Format = foundryMarkdown
+	p.Text.Markdown = markdown
+	p.Text.Content = html
+
+	e.Pages = []foundryJournalPage{p}
+}
+
+// foundryLink returns a content link to e
+func foundryLink(e foundryJournalEntry) string {
+	return fmt.Sprintf("@UUID[JournalEntry.%s]{%s}", e.ID, e.Name)
+}
+
+// foundryIDChars are the characters allowed in Foundry document IDs
+const foundryIDChars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// foundryID returns a 16 character document ID derived from keys. IDs are derived rather than
+// random so that exporting the same sector again gives the same IDs, and the same links.
+func foundryID(keys ...string) string {
+	var (
+		sum = sha1.Sum([]byte(strings.Join(keys, "\x00")))
+		id  = new(bytes.Buffer)
+	)
+
+	for _, b := range sum[:16] {
+		id.WriteByte(foundryIDChars[int(b)%len(foundryIDChars)])
+	}
+
+	return id.String()
+}
+
+// foundryFileName returns the file name of a document, the ID keeps names unique
+func foundryFileName(name, id string) string {
+	return strings.Trim(nonAlnum.ReplaceAllString(strings.ToLower(name), "-"), "-") + "-" + id + ".json"
+}
@@ -0,0 +1,121 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/hexmap"
+)
+
+// HTML represents the Exporter for a single, self contained, HTML page
+type HTML struct {
+	Name  string
+	Stars *sector.Stars
+}
+
+// Write satisfies the Exporter interface. The page embeds the GM map and has a section for each
+// Star, linked from an index that can be searched without a server or any external files.
+func (h *HTML) Write() error {
+	fmt.Println("Exporting as html...")
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, htmlHead, html.EscapeString(h.Name))
+	fmt.Fprint(buf, format.Header(format.HTML, 1, h.Name))
+	fmt.Fprintln(buf, `<input id="search" type="search" placeholder="Search systems, worlds, tags...">`)
+
+	// Index
+	fmt.Fprintln(buf, `<nav><ul id="index">`)
+	for _, s := range h.Stars.Systems {
+		fmt.Fprintf(buf, `<li data-target="%s"><a href="#%s">%s</a> (%s)</li>`+"\n", anchor(s.Name), anchor(s.Name), html.EscapeString(s.Name), s.Hex())
+	}
+	fmt.Fprintln(buf, "</ul></nav>")
+
+	// Map
+	fmt.Fprintln(buf, `<section id="map">`)
+	fmt.Fprint(buf, format.Header(format.HTML, 2, "Map"))
+	fmt.Fprint(buf, hexmap.SVG(h.Stars, false))
+	fmt.Fprintln(buf, "</section>")
+
+	if len(h.Stars.Lanes) > 0 {
+		fmt.Fprintln(buf, `<section id="lanes">`)
+		fmt.Fprint(buf, format.Header(format.HTML, 2, "Lanes"))
+		fmt.Fprint(buf, h.Stars.Lanes.Format(format.HTML))
+		fmt.Fprintln(buf, "</section>")
+	}
+
+	if len(h.Stars.Factions) > 0 {
+		fmt.Fprintln(buf, `<section id="factions">`)
+		fmt.Fprint(buf, format.Header(format.HTML, 2, "Factions"))
+		fmt.Fprint(buf, h.Stars.FormatFactions(format.HTML))
+		for _, f := range h.Stars.Factions {
+			fmt.Fprint(buf, f.Format(format.HTML))
+		}
+		fmt.Fprintln(buf, "</section>")
+	}
+
+	// Stars
+	for _, s := range h.Stars.Systems {
+		fmt.Fprintf(buf, `<section class="star" id="%s">`+"\n", anchor(s.Name))
+		fmt.Fprint(buf, format.Header(format.HTML, 2, s.Name))
+		fmt.Fprint(buf, s.Format(format.HTML))
+		fmt.Fprintln(buf, `<p><a href="#">Back to top</a></p>`)
+		fmt.Fprintln(buf, "</section>")
+	}
+
+	fmt.Fprint(buf, htmlFoot)
+
+	return ioutil.WriteFile(h.Name+".html", buf.Bytes(), filePerm)
+}
+
+var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
+
+// anchor returns an id for an HTML element based on name
+func anchor(name string) string {
+	return "star-" + strings.Trim(nonAlnum.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+const htmlHead = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>%s</title>
+<style>
+body { background: #181818; color: #e5e5e5; font-family: sans-serif; margin: 0 auto; max-width: 60em; padding: 1em; }
+a { color: #11a8cd; }
+h1, h2, h3 { color: #0dbc79; }
+table { border-collapse: collapse; margin: 0.5em 0 1em; width: 100%%; }
+th, td { border: 1px solid #505050; padding: 0.25em 0.5em; text-align: left; vertical-align: top; }
+th { background: #252525; }
+nav ul { columns: 4 10em; list-style: none; padding: 0; }
+#search { font-size: 1em; padding: 0.4em; width: 100%%; box-sizing: border-box; }
+#map svg { height: auto; max-width: 100%%; }
+.star { border-top: 1px solid #505050; }
+.hidden { display: none; }
+</style>
+</head>
+<body>
+`
+
+const htmlFoot = `<script>
+(function () {
+  var search = document.getElementById("search");
+  search.addEventListener("input", function () {
+    var q = search.value.toLowerCase();
+    document.querySelectorAll(".star").forEach(function (s) {
+      var match = q === "" || s.textContent.toLowerCase().indexOf(q) !== -1;
+      s.classList.toggle("hidden", !match);
+      document.querySelector('#index li[data-target="' + s.id + '"]').classList.toggle("hidden", !match);
+    });
+  });
+})();
+</script>
+</body>
+</html>
+`
@@ -1,126 +1,190 @@
 package export
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
 
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/hexmap"
 )
 
+// HugoTheme sets the theme named in the config of Hugo exports. Themes are not downloaded, if one
+// is set it must be installed in the themes directory of the site before it is built. When no theme
+// is set the site is given a minimal set of layouts of its own.
+var HugoTheme = ""
+
 // Hugo represents the Exporter for Hugo projects
 type Hugo struct {
 	Name  string
 	Stars *sector.Stars
 }
 
-// Write satisfies the Setup requirement of the Exporter interface
+// Write satisfies the Setup requirement of the Exporter interface. The site is written directly
+// rather than with the hugo command so no other tools are needed until the site is built.
 func (h *Hugo) Write() error {
 	fmt.Println("Exporting as hugo site...")
-	wdir, _ := os.Getwd()
 
-	hugoDir := "hugo"
-	if err := os.Mkdir(hugoDir, dirPerm); err != nil {
+	var (
+		root     = "hugo"
+		starsDir = filepath.Join(root, "content", "Stars")
+		mapDir   = filepath.Join(root, "static", "Maps")
+		date     = time.Now().Format(time.RFC3339)
+	)
+
+	fmt.Println("Creating hugo site...")
+	if err := os.Mkdir(root, dirPerm); err != nil {
 		return err
 	}
 
-	if err := os.Chdir(hugoDir); err != nil {
-		return err
+	for _, dir := range []string{"archetypes", "content", "data", "layouts", "static", "themes"} {
+		if err := os.Mkdir(filepath.Join(root, dir), dirPerm); err != nil {
+			return err
+		}
 	}
 
-	fmt.Println("Creating new hugo site...")
-	o, err := exec.Command("hugo", "new", "site", ".").CombinedOutput()
-	if err != nil {
+	if err := ioutil.WriteFile(filepath.Join(root, "config.toml"), []byte(h.config()), filePerm); err != nil {
 		return err
 	}
-	fmt.Print(string(o))
 
-	o, err = exec.Command("git", "init").CombinedOutput()
-	if err != nil {
+	if err := ioutil.WriteFile(filepath.Join(root, "archetypes", "default.md"), []byte(hugoArchetype), filePerm); err != nil {
 		return err
 	}
-	fmt.Print(string(o))
 
-	o, err = exec.Command("git", "submodule", "add", "https://github.com/nboughton/hugo-theme-docdock.git", "themes/docdock").CombinedOutput()
-	if err != nil {
-		return err
+	if HugoTheme == "" {
+		fmt.Println("Writing layouts...")
+		layoutDir := filepath.Join(root, "layouts", "_default")
+		if err := os.Mkdir(layoutDir, dirPerm); err != nil {
+			return err
+		}
+
+		for name, layout := range hugoLayouts {
+			if err := ioutil.WriteFile(filepath.Join(layoutDir, name), []byte(layout), filePerm); err != nil {
+				return err
+			}
+		}
 	}
-	fmt.Print(string(o))
 
-	o, err = exec.Command("git", "submodule", "init").CombinedOutput()
-	if err != nil {
+	fmt.Println("Populating Stars dir...")
+	if err := os.Mkdir(starsDir, dirPerm); err != nil {
 		return err
 	}
-	fmt.Print(string(o))
 
-	o, err = exec.Command("git", "submodule", "update").CombinedOutput()
-	if err != nil {
+	if err := ioutil.WriteFile(filepath.Join(starsDir, "_index.md"), frontMatter("Stars", date), filePerm); err != nil {
 		return err
 	}
-	fmt.Print(string(o))
 
-	fmt.Println("Copying config...")
-	_, err = exec.Command("cp", "themes/docdock/exampleSite/config.toml", ".").CombinedOutput()
-	if err != nil {
-		return err
+	for _, star := range h.Stars.Systems {
+		page := append(frontMatter(star.Name, date), []byte(star.Format(format.MARKDOWN))...)
+		if err := ioutil.WriteFile(filepath.Join(starsDir, star.Name+".md"), page, filePerm); err != nil {
+			return err
+		}
 	}
 
-	fmt.Println("Setting Title...")
-	_, err = exec.Command("sed", "-i", fmt.Sprintf("s/TITLE/%s/", h.Name), "config.toml").CombinedOutput()
-	if err != nil {
+	fmt.Println("Writing maps...")
+	if err := os.MkdirAll(mapDir, dirPerm); err != nil {
 		return err
 	}
 
-	fmt.Println("Copying in default archetype...")
-	_, err = exec.Command("cp", "themes/docdock/archetypes/default.md", "archetypes/").CombinedOutput()
-	if err != nil {
+	if err := ioutil.WriteFile(filepath.Join(mapDir, "gm-map.svg"), []byte(hexmap.SVG(h.Stars, false)), filePerm); err != nil {
 		return err
 	}
 
-	fmt.Println("Creating Stars dir...")
-	starsDir := "content/Stars"
-	if err := os.Mkdir(starsDir, dirPerm); err != nil {
+	if err := ioutil.WriteFile(filepath.Join(mapDir, "pc-map.svg"), []byte(hexmap.SVG(h.Stars, true)), filePerm); err != nil {
 		return err
 	}
 
-	fmt.Println("Populating Stars dir...")
-	for _, star := range h.Stars.Systems {
-		// Create article stub
-		o, err := exec.Command("hugo", "new", fmt.Sprintf("Stars/%s.md", star.Name)).CombinedOutput()
-		if err != nil {
-			return err
-		}
-		fmt.Print(string(o))
+	// Print hexmap to index.md
+	index := bytes.NewBuffer(frontMatter(h.Name, date))
+	fmt.Fprint(index, "# "+h.Name+"\n\n```\n"+Hexmap(h.Stars, false, false)+"\n```\n\n![GM Map](Maps/gm-map.svg)\n")
 
-		// Append Star data using Markdown
-		f, err := os.OpenFile(fmt.Sprintf("%s/%s.md", starsDir, star.Name), os.O_APPEND|os.O_WRONLY, filePerm)
-		if err != nil {
-			return err
-		}
+	if len(h.Stars.Lanes) > 0 {
+		fmt.Fprint(index, "\n"+format.Header(format.MARKDOWN, 2, "Lanes")+h.Stars.Lanes.Format(format.MARKDOWN))
+	}
 
-		if _, err := f.Write([]byte(star.Format(format.MARKDOWN))); err != nil {
-			return err
+	if len(h.Stars.Factions) > 0 {
+		fmt.Fprint(index, "\n"+format.Header(format.MARKDOWN, 2, "Factions")+h.Stars.FormatFactions(format.MARKDOWN))
+		for _, fac := range h.Stars.Factions {
+			fmt.Fprint(index, "\n"+fac.Format(format.MARKDOWN))
 		}
-
-		f.Close()
 	}
 
-	// Print hexmap to index.md
-	o, err = exec.Command("hugo", "new", "_index.md").CombinedOutput()
-	if err != nil {
-		return err
-	}
-	fmt.Println(string(o))
+	return ioutil.WriteFile(filepath.Join(root, "content", "_index.md"), index.Bytes(), filePerm)
+}
 
-	f, err := os.OpenFile("content/_index.md", os.O_APPEND|os.O_WRONLY, filePerm)
-	if err != nil {
-		return err
-	}
+// config returns the config.toml of the site
+func (h *Hugo) config() string {
+	buf := new(bytes.Buffer)
 
-	if _, err := f.Write([]byte("# " + h.Name + "\n\n```\n" + Hexmap(h.Stars, false, false) + "\n```")); err != nil {
-		return err
+	fmt.Fprintln(buf, `baseURL = "/"`)
+	fmt.Fprintln(buf, `languageCode = "en-us"`)
+	fmt.Fprintf(buf, "title = %s\n", strconv.Quote(h.Name))
+	fmt.Fprintln(buf, "relativeURLs = true")
+	if HugoTheme != "" {
+		fmt.Fprintf(buf, "theme = %s\n", strconv.Quote(HugoTheme))
 	}
 
-	return os.Chdir(wdir)
+	// Tables and maps are written as markdown that includes raw HTML
+	fmt.Fprintln(buf, "\n[markup.goldmark.renderer]\n  unsafe = true")
+
+	return buf.String()
+}
+
+// frontMatter returns the YAML front matter of a content page
+func frontMatter(title, date string) []byte {
+	return []byte(fmt.Sprintf("---\ntitle: %s\ndate: %s\ndraft: false\n---\n\n", strconv.Quote(title), date))
+}
+
+const hugoArchetype = `---
+title: "{{ replace .Name "-" " " | title }}"
+date: {{ .Date }}
+draft: false
+---
+`
+
+// hugoLayouts are the minimal layouts used when no theme is set
+var hugoLayouts = map[string]string{
+	"baseof.html": `<!DOCTYPE html>
+<html lang="{{ .Site.LanguageCode }}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }} | {{ .Site.Title }}</title>
+<style>
+body { background: #181818; color: #e5e5e5; font-family: sans-serif; margin: 0 auto; max-width: 60em; padding: 1em; }
+a { color: #11a8cd; }
+h1, h2, h3 { color: #0dbc79; }
+table { border-collapse: collapse; margin: 0.5em 0 1em; }
+th, td { border: 1px solid #505050; padding: 0.25em 0.5em; text-align: left; vertical-align: top; }
+pre { overflow-x: auto; }
+img { max-width: 100%; }
+</style>
+</head>
+<body>
+<nav><a href="{{ "/" | relURL }}">{{ .Site.Title }}</a> | <a href="{{ "stars/" | relURL }}">Stars</a></nav>
+<main>
+{{ block "main" . }}{{ end }}
+</main>
+</body>
+</html>
+`,
+	"single.html": `{{ define "main" }}
+<h1>{{ .Title }}</h1>
+{{ .Content }}
+{{ end }}
+`,
+	"list.html": `{{ define "main" }}
+{{ if not .IsHome }}<h1>{{ .Title }}</h1>{{ end }}
+{{ .Content }}
+<ul>
+{{ range .Pages }}<li><a href="{{ .RelPermalink }}">{{ .Title }}</a></li>
+{{ end }}
+</ul>
+{{ end }}
+`,
 }
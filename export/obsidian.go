@@ -0,0 +1,295 @@
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/format"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/hexmap"
+	"gopkg.in/yaml.v2"
+)
+
+// Obsidian represents the Exporter for an Obsidian (or any markdown notes) vault. Each Star, World
+// and POI gets a note of its own, linked together with [[wiki links]].
+type Obsidian struct {
+	Name  string
+	Stars *sector.Stars
+
+	notes map[interface{}]string // Note names by the content they describe
+	used  map[string]bool
+}
+
+// obsidianWorld keeps a world with the star it belongs to
+type obsidianWorld struct {
+	star  *sector.Star
+	world *content.World
+}
+
+// Write satisfies the Exporter interface. The vault is written to the obsidian directory, with
+// the sector and tag index notes at its root.
+func (o *Obsidian) Write() error {
+	fmt.Println("Exporting as obsidian vault...")
+
+	var (
+		root   = "obsidian"
+		worlds []obsidianWorld
+		byTag  = make(map[string][]obsidianWorld)
+	)
+
+	o.notes, o.used = make(map[interface{}]string), make(map[string]bool)
+	o.noteName(o, o.Name)
+
+	// Name every note up front so that links can be written in any order
+	for _, s := range o.Stars.Systems {
+		o.noteName(s, s.Name)
+
+		for i := range s.Worlds {
+			w := obsidianWorld{star: s, world: &s.Worlds[i]}
+			o.noteName(w.world, w.world.Name)
+			worlds = append(worlds, w)
+
+			for _, t := range w.world.Tags {
+				byTag[t.Name] = append(byTag[t.Name], w)
+			}
+		}
+
+		for i := range s.POIs {
+			o.noteName(&s.POIs[i], s.Name+" "+s.POIs[i].Point)
+		}
+	}
+
+	for _, dir := range []string{"Stars", "Worlds", "POIs", "Maps"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), dirPerm); err != nil {
+			return err
+		}
+	}
+
+	// Sector note
+	buf := new(bytes.Buffer)
+	o.frontMatter(buf, yaml.MapSlice{
+		{Key: "type", Value: "sector"},
+		{Key: "rows", Value: o.Stars.Rows},
+		{Key: "cols", Value: o.Stars.Cols},
+		{Key: "seed", Value: o.Stars.Seed},
+	})
+	fmt.Fprintf(buf, "# %s\n\n![[gm-map.svg]]\n\n", o.Name)
+	fmt.Fprint(buf, format.Header(format.MARKDOWN, 2, "Stars"))
+	for _, s := range o.Stars.Systems {
+		fmt.Fprintf(buf, "- %s (%s)\n", o.link(s), s.Hex())
+	}
+	fmt.Fprintf(buf, "\nSee also %s\n", link("Tags"))
+
+	if len(o.Stars.Lanes) > 0 {
+		fmt.Fprint(buf, "\n"+format.Header(format.MARKDOWN, 2, "Lanes"))
+		for _, l := range o.Stars.Lanes {
+			from, err1 := o.Stars.Find(l.From)
+			to, err2 := o.Stars.Find(l.To)
+			if err1 == nil && err2 == nil {
+				fmt.Fprintf(buf, "- %s to %s: %s\n", o.link(from), o.link(to), l.Label)
+			}
+		}
+	}
+
+	if len(o.Stars.Factions) > 0 {
+		fmt.Fprint(buf, "\n"+format.Header(format.MARKDOWN, 2, "Factions")+o.Stars.FormatFactions(format.MARKDOWN))
+		for _, f := range o.Stars.Factions {
+			fmt.Fprint(buf, "\n"+f.Format(format.MARKDOWN))
+		}
+	}
+
+	if err := o.writeNote(root, o.notes[o], buf); err != nil {
+		return err
+	}
+
+	// Star notes
+	for _, s := range o.Stars.Systems {
+		buf := new(bytes.Buffer)
+		o.frontMatter(buf, yaml.MapSlice{
+			{Key: "type", Value: "star"},
+			{Key: "sector", Value: o.Name},
+			{Key: "coords", Value: s.Hex().String()},
+			{Key: "culture", Value: s.Culture.String()},
+			{Key: "tl", Value: s.Worlds[0].TechLevel.Code},
+			{Key: "tags", Value: noteTags(s.Worlds[0])},
+		})
+		fmt.Fprintf(buf, "# %s\n\nHex %s of %s\n\n", s.Name, s.Hex(), o.link(o))
+
+		fmt.Fprint(buf, format.Header(format.MARKDOWN, 2, "Worlds"))
+		for i := range s.Worlds {
+			fmt.Fprintf(buf, "- %s\n", o.link(&s.Worlds[i]))
+		}
+
+		if len(s.POIs) > 0 {
+			fmt.Fprint(buf, "\n"+format.Header(format.MARKDOWN, 2, "Points of Interest"))
+			for i := range s.POIs {
+				fmt.Fprintf(buf, "- %s\n", o.link(&s.POIs[i]))
+			}
+		}
+
+		if err := o.writeNote(filepath.Join(root, "Stars"), o.notes[s], buf); err != nil {
+			return err
+		}
+	}
+
+	// World notes
+	for _, w := range worlds {
+		buf := new(bytes.Buffer)
+		o.frontMatter(buf, yaml.MapSlice{
+			{Key: "type", Value: "world"},
+			{Key: "star", Value: w.star.Name},
+			{Key: "coords", Value: w.star.Hex().String()},
+			{Key: "primary", Value: w.world.Primary},
+			{Key: "culture", Value: w.world.Culture.String()},
+			{Key: "tl", Value: w.world.TechLevel.Code},
+			{Key: "tags", Value: noteTags(*w.world)},
+		})
+		fmt.Fprintf(buf, "# %s\n\nOrbits %s (%s)\n\n", w.world.Name, o.link(w.star), w.star.Hex())
+		fmt.Fprint(buf, w.world.Format(format.MARKDOWN))
+
+		fmt.Fprint(buf, "\n"+format.Header(format.MARKDOWN, 2, "Worlds Sharing Tags"))
+		for _, t := range w.world.Tags {
+			var others []string
+			for _, other := range byTag[t.Name] {
+				if other.world != w.world {
+					others = append(others, o.link(other.world))
+				}
+			}
+
+			if len(others) == 0 {
+				others = []string{"none"}
+			}
+			fmt.Fprintf(buf, "- %s: %s\n", link("Tags#"+t.Name, t.Name), strings.Join(others, ", "))
+		}
+
+		if err := o.writeNote(filepath.Join(root, "Worlds"), o.notes[w.world], buf); err != nil {
+			return err
+		}
+	}
+
+	// POI notes
+	for _, s := range o.Stars.Systems {
+		for i := range s.POIs {
+			p := &s.POIs[i]
+
+			buf := new(bytes.Buffer)
+			o.frontMatter(buf, yaml.MapSlice{
+				{Key: "type", Value: "poi"},
+				{Key: "star", Value: s.Name},
+				{Key: "coords", Value: s.Hex().String()},
+			})
+			fmt.Fprintf(buf, "# %s\n\nIn the %s system (%s)\n\n", p.Point, o.link(s), s.Hex())
+			fmt.Fprint(buf, p.Format(format.MARKDOWN))
+
+			if err := o.writeNote(filepath.Join(root, "POIs"), o.notes[p], buf); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Tag index
+	var tags []string
+	for t := range byTag {
+		tags = append(tags, t)
+	}
+	sort.Strings(tags)
+
+	buf = new(bytes.Buffer)
+	o.frontMatter(buf, yaml.MapSlice{{Key: "type", Value: "tags"}, {Key: "sector", Value: o.Name}})
+	fmt.Fprintf(buf, "# Tags\n\nWorld tags in use in %s\n\n", o.link(o))
+	for _, name := range tags {
+		fmt.Fprint(buf, format.Header(format.MARKDOWN, 2, name))
+		if t, err := content.Tags.Find(name); err == nil {
+			fmt.Fprintf(buf, "%s\n\n", t.Desc)
+		}
+
+		for _, w := range byTag[name] {
+			fmt.Fprintf(buf, "- %s (%s)\n", o.link(w.world), o.link(w.star))
+		}
+		fmt.Fprintln(buf)
+	}
+
+	if err := o.writeNote(root, "Tags", buf); err != nil {
+		return err
+	}
+
+	// Maps
+	mapDir := filepath.Join(root, "Maps")
+	if err := ioutil.WriteFile(filepath.Join(mapDir, "gm-map.svg"), []byte(hexmap.SVG(o.Stars, false)), filePerm); err != nil {
+		return err
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(mapDir, "pc-map.svg"), []byte(hexmap.SVG(o.Stars, true)), filePerm); err != nil {
+		return err
+	}
+
+	img, err := hexmap.PNG(o.Stars, false, MapPixelSize)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(filepath.Join(mapDir, "gm-map.png"), img, filePerm)
+}
+
+var unsafeNoteChars = regexp.MustCompile(`[\\/:*?"<>|#^\[\]]`)
+
+// noteName assigns a unique note name to c, based on n, and returns it. Obsidian resolves links by
+// note name alone so names must be unique across the whole vault.
+func (o *Obsidian) noteName(c interface{}, n string) string {
+	n = strings.TrimSpace(unsafeNoteChars.ReplaceAllString(n, ""))
+	if n == "" || n == "Tags" {
+		n += " Note"
+	}
+
+	name := n
+	for i := 2; o.used[name]; i++ {
+		name = fmt.Sprintf("%s %d", n, i)
+	}
+
+	o.used[name] = true
+	o.notes[c] = name
+
+	return name
+}
+
+// link returns a wiki link to the note for c
+func (o *Obsidian) link(c interface{}) string {
+	return link(o.notes[c])
+}
+
+// link returns a wiki link to note, with optional display text
+func link(note string, text ...string) string {
+	if len(text) > 0 {
+		return fmt.Sprintf("[[%s|%s]]", note, text[0])
+	}
+
+	return fmt.Sprintf("[[%s]]", note)
+}
+
+// noteTags converts the tags of w to Obsidian tags, which can't contain spaces
+func noteTags(w content.World) []string {
+	var tags []string
+	for _, t := range w.Tags {
+		tags = append(tags, strings.ToLower(strings.Join(strings.Fields(t.Name), "-")))
+	}
+
+	return tags
+}
+
+// frontMatter writes fields to buf as YAML front matter
+func (o *Obsidian) frontMatter(buf *bytes.Buffer, fields yaml.MapSlice) {
+	b, _ := yaml.Marshal(fields)
+	fmt.Fprintf(buf, "---\n%s---\n\n", b)
+}
+
+// writeNote writes the note called name to dir
+func (o *Obsidian) writeNote(dir, name string, buf *bytes.Buffer) error {
+	return ioutil.WriteFile(filepath.Join(dir, name+".md"), buf.Bytes(), filePerm)
+}
@@ -0,0 +1,309 @@
+package export
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/sector"
+)
+
+// SEC represents the Exporter for Traveller style tab delimited sector data. Each Star is a row
+// with its hex (CCRR, counting from 01), name, a UWP-like code and its primary world's tags as
+// remarks. Traveller has one world per hex so other worlds and points of interest are not
+// exported.
+//
+// The code has a digit for each of the primary world's Atmosphere, Temperature, Biosphere and
+// Population, followed by a dash and its Tech Level, i.e "3433-4". Digits count from 0 in the order
+// of the world tables except Tech Levels, which are in ascending order so TL4+ is 5 and TL5 is 6.
+// Values that aren't from the world tables (i.e from imported sectors) are written as X.
+type SEC struct {
+	Name  string
+	Stars *sector.Stars
+}
+
+// secUnknown is the value of world attributes written as X
+const secUnknown = "Unknown"
+
+// secTechLevels lists Tech Level codes in ascending order
+var secTechLevels = []string{"TL0", "TL1", "TL2", "TL3", "TL4", "TL4+", "TL5"}
+
+// secColumns are the column headers, in order
+var secColumns = []string{"Hex", "Name", "UWP", "Remarks", "World", "Culture"}
+
+// Write satisfies the Exporter interface
+func (s *SEC) Write() error {
+	fmt.Println("Exporting as sec...")
+
+	buf := new(bytes.Buffer)
+	fmt.Fprintf(buf, "# Sector: %s\n", s.Name)
+	fmt.Fprintf(buf, "# Rows: %d\n", s.Stars.Rows)
+	fmt.Fprintf(buf, "# Cols: %d\n", s.Stars.Cols)
+	fmt.Fprintf(buf, "# Seed: %d\n", s.Stars.Seed)
+	fmt.Fprintln(buf, strings.Join(secColumns, "\t"))
+
+	for _, star := range s.Stars.Systems {
+		w := star.Worlds[0]
+		fmt.Fprintln(buf, strings.Join([]string{
+			fmt.Sprintf("%02d%02d", star.Col+1, star.Row+1),
+			star.Name,
+			secCode(w),
+			w.Tags[0].Name + ", " + w.Tags[1].Name,
+			w.Name,
+			w.Culture.String(),
+		}, "\t"))
+	}
+
+	return ioutil.WriteFile(s.Name+".tab", buf.Bytes(), filePerm)
+}
+
+// secCode returns the UWP-like code of w
+func secCode(w content.World) string {
+	var (
+		values = content.WorldAttributeValues()
+		code   string
+	)
+
+	for _, a := range []struct {
+		values []content.Attribute
+		code   string
+	}{
+		{values.Atmosphere, w.Atmosphere.Code},
+		{values.Temperature, w.Temperature.Code},
+		{values.Biosphere, w.Biosphere.Code},
+		{values.Population, w.Population.Code},
+	} {
+		code += secDigit(secIndex(a.values, a.code))
+	}
+
+	tl := -1
+	for i, c := range secTechLevels {
+		if c == w.TechLevel.Code {
+			tl = i
+		}
+	}
+
+	return code + "-" + secDigit(tl)
+}
+
+// secIndex returns the index of the value with code in values or -1
+func secIndex(values []content.Attribute, code string) int {
+	for i, v := range values {
+		if v.Code == code {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// secDigit returns i as a digit, or X if it is out of range
+func secDigit(i int) string {
+	if i < 0 || i > 9 {
+		return "X"
+	}
+
+	return strconv.Itoa(i)
+}
+
+// ImportSEC reads a sector written by the SEC Exporter and returns its name and Stars. Only the
+// Hex, Name and UWP columns are required, a World column sets the primary world's name which
+// otherwise matches the Star. Each Star needs a name and hex of its own, names are compared ignoring
+// case. Sectors without Rows and Cols comments are sized to fit their Stars.
+func ImportSEC(path string) (string, *sector.Stars, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", nil, err
+	}
+	defer f.Close()
+
+	var (
+		name    string
+		stars   = new(sector.Stars)
+		columns map[string]int
+		line    int
+		hexes   = make(map[sector.Hex]int) // Lines of Stars by position
+	)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line++
+
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if strings.HasPrefix(text, "#") {
+			if err := secComment(text, &name, stars); err != nil {
+				return "", nil, fmt.Errorf("line %d: %s", line, err)
+			}
+			continue
+		}
+
+		fields := strings.Split(text, "\t")
+		if columns == nil {
+			columns = make(map[string]int)
+			for i, c := range fields {
+				columns[strings.ToLower(strings.TrimSpace(c))] = i
+			}
+
+			for _, c := range []string{"hex", "name", "uwp"} {
+				if _, ok := columns[c]; !ok {
+					return "", nil, fmt.Errorf("%s has no %s column", path, c)
+				}
+			}
+			continue
+		}
+
+		star, err := secStar(fields, columns)
+		if err != nil {
+			return "", nil, fmt.Errorf("line %d: %s", line, err)
+		}
+
+		if star.Name == "" {
+			return "", nil, fmt.Errorf("line %d: system has no name", line)
+		}
+
+		if stars.NameUsed(star.Name, nil) {
+			return "", nil, fmt.Errorf("line %d: there is already a system called \"%s\"", line, star.Name)
+		}
+
+		h := star.Hex()
+		if other, ok := hexes[h]; ok {
+			return "", nil, fmt.Errorf("line %d: hex %02d%02d is already used by the system on line %d", line, star.Col+1, star.Row+1, other)
+		}
+		hexes[h] = line
+
+		stars.Systems = append(stars.Systems, star)
+
+		if star.Row >= stars.Rows {
+			stars.Rows = star.Row + 1
+		}
+		if star.Col >= stars.Cols {
+			stars.Cols = star.Col + 1
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", nil, err
+	}
+
+	return name, stars, nil
+}
+
+// secComment reads the sector details from a header comment. Unknown comments are ignored.
+func secComment(text string, name *string, stars *sector.Stars) error {
+	kv := strings.SplitN(strings.TrimPrefix(text, "#"), ":", 2)
+	if len(kv) != 2 {
+		return nil
+	}
+
+	var (
+		key   = strings.ToLower(strings.TrimSpace(kv[0]))
+		value = strings.TrimSpace(kv[1])
+		err   error
+	)
+
+	switch key {
+	case "sector":
+		*name = value
+	case "rows":
+		stars.Rows, err = strconv.Atoi(value)
+	case "cols":
+		stars.Cols, err = strconv.Atoi(value)
+	case "seed":
+		stars.Seed, err = strconv.ParseInt(value, 10, 64)
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s \"%s\"", key, value)
+	}
+
+	return nil
+}
+
+// secStar returns the Star described by a row of fields
+func secStar(fields []string, columns map[string]int) (*sector.Star, error) {
+	field := func(c string) string {
+		if i, ok := columns[c]; ok && i < len(fields) {
+			return strings.TrimSpace(fields[i])
+		}
+
+		return ""
+	}
+
+	hex := field("hex")
+	if len(hex) != 4 {
+		return nil, fmt.Errorf("invalid hex \"%s\"", hex)
+	}
+
+	col, err1 := strconv.Atoi(hex[:2])
+	row, err2 := strconv.Atoi(hex[2:])
+	if err1 != nil || err2 != nil || col < 1 || row < 1 {
+		return nil, fmt.Errorf("invalid hex \"%s\"", hex)
+	}
+
+	uwp := field("uwp")
+	if len(uwp) != 6 || uwp[4] != '-' {
+		return nil, fmt.Errorf("invalid uwp \"%s\"", uwp)
+	}
+
+	star := &sector.Star{
+		Row:     row - 1,
+		Col:     col - 1,
+		Name:    field("name"),
+		Culture: swnCulture(field("culture")),
+	}
+
+	values := content.WorldAttributeValues()
+	w := content.World{
+		Primary:     true,
+		Name:        field("world"),
+		Culture:     star.Culture,
+		Atmosphere:  content.ParseAtmosphere(secValue(values.Atmosphere, uwp[0])),
+		Temperature: content.ParseTemperature(secValue(values.Temperature, uwp[1])),
+		Biosphere:   content.ParseBiosphere(secValue(values.Biosphere, uwp[2])),
+		Population:  content.ParsePopulation(secValue(values.Population, uwp[3])),
+		TechLevel:   content.ParseTechLevel(secUnknown),
+	}
+
+	if w.Name == "" {
+		w.Name = star.Name
+	}
+
+	if i, err := strconv.Atoi(uwp[5:]); err == nil && i < len(secTechLevels) {
+		w.TechLevel = content.ParseTechLevel(secTechLevels[i])
+	}
+
+	for i, t := range strings.Split(field("remarks"), ",") {
+		t = strings.TrimSpace(t)
+		if i >= len(w.Tags) || t == "" {
+			continue
+		}
+
+		tag, err := content.Tags.Find(t)
+		if err != nil { // Tags from packs that aren't loaded are kept by name
+			tag = content.Tag{Name: t}
+		}
+		w.Tags[i] = tag
+	}
+
+	star.Worlds = []content.World{w}
+
+	return star, nil
+}
+
+// secValue returns the code of the value at digit d, or Unknown
+func secValue(values []content.Attribute, d byte) string {
+	if i, err := strconv.Atoi(string(d)); err == nil && i < len(values) {
+		return values[i].Code
+	}
+
+	return secUnknown
+}
@@ -0,0 +1,104 @@
+package export
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/dice"
+)
+
+func TestSECRoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, tc := range []struct {
+		name       string
+		seed       int64
+		rows, cols int
+		density    sector.Density
+	}{
+		{"sparse", 1, 10, 8, sector.SPARSE},
+		{"average", 2, 8, 10, sector.AVERAGE},
+		{"dense", 3, 12, 12, sector.DENSE},
+	} {
+		stars, err := sector.NewSector(dice.New(tc.seed), tc.rows, tc.cols, nil, false, 30, 10, tc.density)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		}
+
+		path := filepath.Join(dir, tc.name)
+		if err := (&SEC{Name: path, Stars: stars}).Write(); err != nil {
+			t.Fatalf("%s: exporting: %s", tc.name, err)
+		}
+
+		name, got, err := ImportSEC(path + ".tab")
+		if err != nil {
+			t.Fatalf("%s: importing: %s", tc.name, err)
+		}
+
+		if name != path || got.Rows != stars.Rows || got.Cols != stars.Cols || got.Seed != stars.Seed {
+			t.Errorf("%s: imported %s (%dx%d, seed %d), want %s (%dx%d, seed %d)", tc.name, name, got.Rows, got.Cols, got.Seed, path, stars.Rows, stars.Cols, stars.Seed)
+		}
+
+		if len(got.Systems) != len(stars.Systems) {
+			t.Fatalf("%s: imported %d systems, want %d", tc.name, len(got.Systems), len(stars.Systems))
+		}
+
+		// Only the primary world of each Star is exported
+		for i, s := range stars.Systems {
+			g := got.Systems[i]
+			if g.Name != s.Name || g.Row != s.Row || g.Col != s.Col || g.Culture != s.Culture {
+				t.Errorf("%s: system %s at %d,%d (%s) imported as %s at %d,%d (%s)", tc.name, s.Name, s.Row, s.Col, s.Culture, g.Name, g.Row, g.Col, g.Culture)
+				continue
+			}
+
+			if len(g.Worlds) != 1 {
+				t.Errorf("%s: system %s imported with %d worlds, want 1", tc.name, s.Name, len(g.Worlds))
+				continue
+			}
+
+			w, gw := s.Worlds[0], g.Worlds[0]
+			compareWorlds(t, s.Name, w, gw)
+
+			if gw.Culture != w.Culture || gw.Tags[0].Name != w.Tags[0].Name || gw.Tags[1].Name != w.Tags[1].Name {
+				t.Errorf("%s: world %s (%s, %s, %s) imported as (%s, %s, %s)", tc.name, w.Name, w.Culture, w.Tags[0].Name, w.Tags[1].Name, gw.Culture, gw.Tags[0].Name, gw.Tags[1].Name)
+			}
+		}
+	}
+}
+
+func TestImportSECErrors(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	const header = "# Sector: Test\nHex\tName\tUWP\n"
+
+	for _, tc := range []struct {
+		name, rows, err string
+	}{
+		{"invalid hex", "0A01\tAleph\t3433-4\n", "line 3"},
+		{"invalid uwp", "0101\tAleph\t34334\n", "line 3"},
+		{"no name", "0101\tAleph\t3433-4\n0102\t \t3433-4\n", "line 4"},
+		{"shared name", "0101\tAleph\t3433-4\n0102\tBeth\t3433-4\n0103\taleph\t3433-4\n", "line 5"},
+		{"shared hex", "0101\tAleph\t3433-4\n0102\tBeth\t3433-4\n0101\tGimel\t3433-4\n", "line 5"},
+	} {
+		path := filepath.Join(dir, "errors.tab")
+		if err := ioutil.WriteFile(path, []byte(header+tc.rows), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, _, err := ImportSEC(path)
+		if err == nil {
+			t.Errorf("%s: import returned no error", tc.name)
+			continue
+		}
+
+		if !strings.HasPrefix(err.Error(), tc.err) {
+			t.Errorf("%s: import returned \"%s\", want an error on %s", tc.name, err, tc.err)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package export
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/nboughton/go-utils/json/file"
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/culture"
+	"github.com/nboughton/swnt/content/sector"
+)
+
+// SWN represents the Exporter for the JSON format of sectorswithoutnumber.com. The site has no
+// equivalent of Lanes or Factions so they are not exported. Data the site doesn't use, such as
+// culture and the origins of other worlds, is kept in the attributes of each entity so that
+// sectors survive the round trip back into swnt.
+type SWN struct {
+	Name  string
+	Stars *sector.Stars
+}
+
+// swnDoc is the top level of a sectorswithoutnumber.com export. Each entity type maps IDs to entities.
+type swnDoc map[string]map[string]swnEntity
+
+// swnEntity is any sector, system, planet or point of interest. Coordinates start at 1.
+type swnEntity struct {
+	Name         string        `json:"name"`
+	Parent       string        `json:"parent,omitempty"`
+	ParentEntity string        `json:"parentEntity,omitempty"`
+	X            int           `json:"x,omitempty"`
+	Y            int           `json:"y,omitempty"`
+	Rows         int           `json:"rows,omitempty"`
+	Columns      int           `json:"columns,omitempty"`
+	IsHidden     bool          `json:"isHidden"`
+	Attributes   swnAttributes `json:"attributes"`
+}
+
+// swnAttributes are the attributes of all entity types
+type swnAttributes struct {
+	Tags         []string `json:"tags,omitempty"`
+	Atmosphere   string   `json:"atmosphere,omitempty"`
+	Temperature  string   `json:"temperature,omitempty"`
+	Biosphere    string   `json:"biosphere,omitempty"`
+	Population   string   `json:"population,omitempty"`
+	TechLevel    string   `json:"techLevel,omitempty"`
+	Occupation   string   `json:"occupation,omitempty"`
+	Situation    string   `json:"situation,omitempty"`
+	Description  string   `json:"description,omitempty"`
+	Culture      string   `json:"culture,omitempty"`
+	Origin       string   `json:"origin,omitempty"`
+	Relationship string   `json:"relationship,omitempty"`
+	Contact      string   `json:"contact,omitempty"`
+	Seed         int64    `json:"seed,omitempty"`
+}
+
+// swnCode pairs a code used by the site with the text of the matching swnt table item
+type swnCode struct {
+	Code, Text string
+}
+
+// swnPOICodes lists the codes of each type of point of interest in the order of the poi table.
+// World attributes use the same codes as the site.
+var swnPOICodes = []swnCode{
+	{"deepSpaceStation", "Deep-space station"},
+	{"asteroidBase", "Asteroid base"},
+	{"moonBase", "Remote moon base"},
+	{"orbitalRuin", "Ancient orbital ruin"},
+	{"researchBase", "Research base"},
+	{"asteroidBelt", "Asteroid belt"},
+	{"gasGiantMine", "Gas giant mine"},
+	{"refuelingStation", "Refueling station"},
+}
+
+// swnCodeOf returns the code of text, text that has no code (i.e from a user defined table) is
+// returned as it is
+func swnCodeOf(codes []swnCode, text string) string {
+	for _, c := range codes {
+		if c.Text == text {
+			return c.Code
+		}
+	}
+
+	return text
+}
+
+// swnTextOf returns the text of code, codes that are not known are returned as they are
+func swnTextOf(codes []swnCode, code string) string {
+	for _, c := range codes {
+		if c.Code == code {
+			return c.Text
+		}
+	}
+
+	return code
+}
+
+// camelCase returns s as a camel case identifier, i.e "Abandoned Colony" becomes "abandonedColony"
+func camelCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+	for i, w := range words {
+		if i == 0 {
+			words[i] = strings.ToLower(w)
+		} else {
+			words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+		}
+	}
+
+	return strings.Join(words, "")
+}
+
+// Write satisfies the Exporter interface
+func (s *SWN) Write() error {
+	fmt.Println("Exporting as sectors without number json...")
+
+	doc := swnDoc{
+		"sector": {},
+		"system": {},
+		"planet": {},
+	}
+	for _, c := range swnPOICodes {
+		doc[c.Code] = make(map[string]swnEntity)
+	}
+
+	sectorID := "sector-1"
+	doc["sector"][sectorID] = swnEntity{
+		Name:       s.Name,
+		Rows:       s.Stars.Rows,
+		Columns:    s.Stars.Cols,
+		Attributes: swnAttributes{Seed: s.Stars.Seed},
+	}
+
+	planets, pois := 0, 0
+	for i, star := range s.Stars.Systems {
+		systemID := fmt.Sprintf("system-%04d", i+1)
+		doc["system"][systemID] = swnEntity{
+			Name:         star.Name,
+			Parent:       sectorID,
+			ParentEntity: "sector",
+			X:            star.Col + 1,
+			Y:            star.Row + 1,
+			Attributes:   swnAttributes{Culture: star.Culture.String()},
+		}
+
+		for _, w := range star.Worlds {
+			planets++
+
+			var tags []string
+			for _, t := range w.Tags {
+				tags = append(tags, camelCase(t.Name))
+			}
+
+			doc["planet"][fmt.Sprintf("planet-%04d", planets)] = swnEntity{
+				Name:         w.Name,
+				Parent:       systemID,
+				ParentEntity: "system",
+				Attributes: swnAttributes{
+					Tags:         tags,
+					Atmosphere:   w.Atmosphere.Code,
+					Temperature:  w.Temperature.Code,
+					Biosphere:    w.Biosphere.Code,
+					Population:   w.Population.Code,
+					TechLevel:    w.TechLevel.Code,
+					Culture:      w.Culture.String(),
+					Origin:       w.Origin,
+					Relationship: w.Relationship,
+					Contact:      w.Contact,
+				},
+			}
+		}
+
+		for _, p := range star.POIs {
+			pois++
+
+			entity := swnCodeOf(swnPOICodes, p.Point)
+			if _, ok := doc[entity]; !ok {
+				doc[entity] = make(map[string]swnEntity)
+			}
+
+			doc[entity][fmt.Sprintf("%s-%04d", entity, pois)] = swnEntity{
+				Name:         p.Point,
+				Parent:       systemID,
+				ParentEntity: "system",
+				Attributes:   swnAttributes{Occupation: p.Occupied, Situation: p.Situation},
+			}
+		}
+	}
+
+	return file.Write(s.Name+".swn.json", doc)
+}
+
+// ImportSWN reads a sector exported from sectorswithoutnumber.com, or by the SWN Exporter, and
+// returns its name and Stars. The first planet of each system (in ID order) becomes its primary
+// world and systems without planets are skipped as every Star needs a primary world. Systems
+// without a position, or that share a hex with another system, are an error.
+func ImportSWN(path string) (string, *sector.Stars, error) {
+	doc := make(swnDoc)
+	if err := file.Scan(path, &doc); err != nil {
+		return "", nil, err
+	}
+
+	if len(doc["sector"]) == 0 {
+		return "", nil, fmt.Errorf("%s does not contain a sector", path)
+	}
+
+	var (
+		name  string
+		stars = new(sector.Stars)
+	)
+	for _, id := range swnIDs(doc["sector"]) {
+		sec := doc["sector"][id]
+		name, stars.Rows, stars.Cols, stars.Seed = sec.Name, sec.Rows, sec.Columns, sec.Attributes.Seed
+		break
+	}
+
+	// Planets and points of interest by the ID of the system they belong to
+	var (
+		planets = make(map[string][]swnEntity)
+		pois    = make(map[string][]content.POI)
+	)
+	for _, id := range swnIDs(doc["planet"]) {
+		p := doc["planet"][id]
+		planets[p.Parent] = append(planets[p.Parent], p)
+	}
+
+	for _, c := range swnPOICodes {
+		for _, id := range swnIDs(doc[c.Code]) {
+			p := doc[c.Code][id]
+
+			parent := p.Parent
+			if p.ParentEntity == "planet" { // Moon bases and the like may orbit a planet rather than the star
+				parent = doc["planet"][p.Parent].Parent
+			}
+
+			pois[parent] = append(pois[parent], content.POI{
+				Point:     c.Text,
+				Occupied:  p.Attributes.Occupation,
+				Situation: p.Attributes.Situation,
+			})
+		}
+	}
+
+	hexes := make(map[sector.Hex]string) // System names by position
+	for _, id := range swnIDs(doc["system"]) {
+		sys := doc["system"][id]
+		if len(planets[id]) == 0 {
+			fmt.Printf("Skipping system %s, it has no planets\n", sys.Name)
+			continue
+		}
+
+		if sys.X < 1 || sys.Y < 1 {
+			return "", nil, fmt.Errorf("system %s has an invalid hex (x %d, y %d)", sys.Name, sys.X, sys.Y)
+		}
+
+		h := sector.Hex{Row: sys.Y - 1, Col: sys.X - 1}
+		if other, ok := hexes[h]; ok {
+			return "", nil, fmt.Errorf("systems %s and %s are both in hex x %d, y %d", other, sys.Name, sys.X, sys.Y)
+		}
+		hexes[h] = sys.Name
+
+		star := &sector.Star{
+			Row:     h.Row,
+			Col:     h.Col,
+			Name:    sys.Name,
+			Culture: swnCulture(sys.Attributes.Culture),
+			POIs:    pois[id],
+		}
+
+		for i, p := range planets[id] {
+			w := content.World{
+				Primary:      i == 0,
+				Name:         p.Name,
+				Culture:      star.Culture,
+				Atmosphere:   content.ParseAtmosphere(p.Attributes.Atmosphere),
+				Temperature:  content.ParseTemperature(p.Attributes.Temperature),
+				Biosphere:    content.ParseBiosphere(p.Attributes.Biosphere),
+				Population:   content.ParsePopulation(p.Attributes.Population),
+				TechLevel:    content.ParseTechLevel(p.Attributes.TechLevel),
+				Origin:       p.Attributes.Origin,
+				Relationship: p.Attributes.Relationship,
+				Contact:      p.Attributes.Contact,
+			}
+
+			if p.Attributes.Culture != "" {
+				w.Culture = swnCulture(p.Attributes.Culture)
+			}
+
+			for j := 0; j < len(w.Tags) && j < len(p.Attributes.Tags); j++ {
+				w.Tags[j] = swnTag(p.Attributes.Tags[j])
+			}
+
+			star.Worlds = append(star.Worlds, w)
+		}
+
+		stars.Systems = append(stars.Systems, star)
+
+		// The site allows sectors to be resized after systems are placed
+		if star.Row >= stars.Rows {
+			stars.Rows = star.Row + 1
+		}
+		if star.Col >= stars.Cols {
+			stars.Cols = star.Col + 1
+		}
+	}
+
+	return name, stars, nil
+}
+
+// swnIDs returns the IDs of entities in sorted order so that imports are consistent
+func swnIDs(entities map[string]swnEntity) []string {
+	var ids []string
+	for id := range entities {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+// swnTag returns the tag matching code, or a tag named after code if there isn't one
+func swnTag(code string) content.Tag {
+	for _, t := range content.Tags {
+		if camelCase(t.Name) == code {
+			return t
+		}
+	}
+
+	return content.Tag{Name: code}
+}
+
+// swnCulture returns the culture named c, which the site doesn't record
+func swnCulture(c string) culture.Culture {
+	for _, ctr := range culture.Cultures {
+		if ctr.String() == c {
+			return ctr
+		}
+	}
+
+	return culture.Any
+}
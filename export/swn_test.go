@@ -0,0 +1,151 @@
+package export
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nboughton/swnt/content"
+	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/dice"
+)
+
+// tempDir returns a new temporary directory, callers remove it when they're done
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "swnt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir
+}
+
+// compareWorlds reports the differences between the world w and the world got read back from an export
+func compareWorlds(t *testing.T, star string, w, got content.World) {
+	if got.Name != w.Name || got.Primary != w.Primary {
+		t.Errorf("%s: world %s (primary %t) imported as %s (primary %t)", star, w.Name, w.Primary, got.Name, got.Primary)
+	}
+
+	for _, a := range []struct {
+		name      string
+		want, got content.Attribute
+	}{
+		{"atmosphere", w.Atmosphere.Attribute, got.Atmosphere.Attribute},
+		{"temperature", w.Temperature.Attribute, got.Temperature.Attribute},
+		{"biosphere", w.Biosphere.Attribute, got.Biosphere.Attribute},
+		{"population", w.Population.Attribute, got.Population.Attribute},
+		{"tech level", w.TechLevel.Attribute, got.TechLevel.Attribute},
+	} {
+		if a.got.Code != a.want.Code || a.got.Desc != a.want.Desc {
+			t.Errorf("%s: world %s %s is %s (%s), want %s (%s)", star, w.Name, a.name, a.got.Code, a.got.Desc, a.want.Code, a.want.Desc)
+		}
+	}
+}
+
+func TestSWNRoundTrip(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, tc := range []struct {
+		name       string
+		seed       int64
+		rows, cols int
+		density    sector.Density
+	}{
+		{"sparse", 1, 10, 8, sector.SPARSE},
+		{"average", 2, 8, 10, sector.AVERAGE},
+		{"dense", 3, 12, 12, sector.DENSE},
+	} {
+		stars, err := sector.NewSector(dice.New(tc.seed), tc.rows, tc.cols, nil, false, 30, 10, tc.density)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		}
+
+		path := filepath.Join(dir, tc.name)
+		if err := (&SWN{Name: path, Stars: stars}).Write(); err != nil {
+			t.Fatalf("%s: exporting: %s", tc.name, err)
+		}
+
+		name, got, err := ImportSWN(path + ".swn.json")
+		if err != nil {
+			t.Fatalf("%s: importing: %s", tc.name, err)
+		}
+
+		if name != path || got.Rows != stars.Rows || got.Cols != stars.Cols || got.Seed != stars.Seed {
+			t.Errorf("%s: imported %s (%dx%d, seed %d), want %s (%dx%d, seed %d)", tc.name, name, got.Rows, got.Cols, got.Seed, path, stars.Rows, stars.Cols, stars.Seed)
+		}
+
+		if len(got.Systems) != len(stars.Systems) {
+			t.Fatalf("%s: imported %d systems, want %d", tc.name, len(got.Systems), len(stars.Systems))
+		}
+
+		for i, s := range stars.Systems {
+			g := got.Systems[i]
+			if g.Name != s.Name || g.Row != s.Row || g.Col != s.Col || g.Culture != s.Culture {
+				t.Errorf("%s: system %s at %d,%d (%s) imported as %s at %d,%d (%s)", tc.name, s.Name, s.Row, s.Col, s.Culture, g.Name, g.Row, g.Col, g.Culture)
+				continue
+			}
+
+			if len(g.Worlds) != len(s.Worlds) || len(g.POIs) != len(s.POIs) {
+				t.Errorf("%s: system %s imported with %d worlds and %d POIs, want %d and %d", tc.name, s.Name, len(g.Worlds), len(g.POIs), len(s.Worlds), len(s.POIs))
+				continue
+			}
+
+			for j, w := range s.Worlds {
+				compareWorlds(t, s.Name, w, g.Worlds[j])
+
+				gw := g.Worlds[j]
+				if gw.Culture != w.Culture || gw.Tags[0].Name != w.Tags[0].Name || gw.Tags[1].Name != w.Tags[1].Name {
+					t.Errorf("%s: world %s (%s, %s, %s) imported as (%s, %s, %s)", tc.name, w.Name, w.Culture, w.Tags[0].Name, w.Tags[1].Name, gw.Culture, gw.Tags[0].Name, gw.Tags[1].Name)
+				}
+
+				if gw.Origin != w.Origin || gw.Relationship != w.Relationship || gw.Contact != w.Contact {
+					t.Errorf("%s: world %s relationship imported as %q, %q, %q", tc.name, w.Name, gw.Origin, gw.Relationship, gw.Contact)
+				}
+			}
+
+			for j, p := range s.POIs {
+				if g.POIs[j] != p {
+					t.Errorf("%s: system %s POI %+v imported as %+v", tc.name, s.Name, p, g.POIs[j])
+				}
+			}
+		}
+	}
+}
+
+func TestImportSWNErrors(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	for _, tc := range []struct {
+		name, doc string
+	}{
+		{"no sector", `{"system": {}}`},
+		{"invalid hex", `{
+			"sector": {"sector-1": {"name": "Test", "rows": 10, "columns": 8}},
+			"system": {"system-1": {"name": "Aleph", "parent": "sector-1", "x": 0, "y": 1}},
+			"planet": {"planet-1": {"name": "Aleph", "parent": "system-1"}}
+		}`},
+		{"shared hex", `{
+			"sector": {"sector-1": {"name": "Test", "rows": 10, "columns": 8}},
+			"system": {
+				"system-1": {"name": "Aleph", "parent": "sector-1", "x": 2, "y": 3},
+				"system-2": {"name": "Beth", "parent": "sector-1", "x": 2, "y": 3}
+			},
+			"planet": {
+				"planet-1": {"name": "Aleph", "parent": "system-1"},
+				"planet-2": {"name": "Beth", "parent": "system-2"}
+			}
+		}`},
+	} {
+		path := filepath.Join(dir, "errors.swn.json")
+		if err := ioutil.WriteFile(path, []byte(tc.doc), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := ImportSWN(path); err == nil {
+			t.Errorf("%s: import returned no error", tc.name)
+		}
+	}
+}
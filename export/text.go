@@ -9,6 +9,7 @@ import (
 
 	"github.com/nboughton/swnt/content/format"
 	"github.com/nboughton/swnt/content/sector"
+	"github.com/nboughton/swnt/hexmap"
 )
 
 // Text represents the Exporter for text based output
@@ -20,6 +21,7 @@ type Text struct {
 func (t *Text) Write() error {
 	fmt.Println("Exporting as plain text...")
 	wdir, _ := os.Getwd()
+	defer os.Chdir(wdir)
 
 	textDir := "text"
 	if err := os.Mkdir(textDir, dirPerm); err != nil {
@@ -46,6 +48,30 @@ func (t *Text) Write() error {
 		ioutil.WriteFile(starsDir+"/"+system.Name+".txt", buf.Bytes(), filePerm)
 	}
 
+	if len(t.Stars.Lanes) > 0 {
+		buf := new(bytes.Buffer)
+		tab := tabwriter.NewWriter(buf, 1, 2, 1, ' ', 0)
+
+		fmt.Fprint(tab, t.Stars.Lanes.Format(format.TEXT))
+		tab.Flush()
+
+		ioutil.WriteFile("Lanes.txt", buf.Bytes(), filePerm)
+	}
+
+	if len(t.Stars.Factions) > 0 {
+		buf := new(bytes.Buffer)
+		tab := tabwriter.NewWriter(buf, 1, 2, 1, ' ', 0)
+
+		fmt.Fprint(tab, t.Stars.FormatFactions(format.TEXT))
+		for _, f := range t.Stars.Factions {
+			fmt.Fprintln(tab)
+			fmt.Fprint(tab, f.Format(format.TEXT))
+		}
+		tab.Flush()
+
+		ioutil.WriteFile("Factions.txt", buf.Bytes(), filePerm)
+	}
+
 	mapDir := "Maps"
 	if err := os.Mkdir(mapDir, dirPerm); err != nil {
 		return err
@@ -55,6 +81,17 @@ func (t *Text) Write() error {
 	ioutil.WriteFile(mapDir+"/pc-map.txt", []byte(Hexmap(t.Stars, false, true)), filePerm)
 	ioutil.WriteFile(mapDir+"/gm-map-ansi.txt", []byte(Hexmap(t.Stars, true, false)), filePerm)
 	ioutil.WriteFile(mapDir+"/pc-map-ansi.txt", []byte(Hexmap(t.Stars, true, true)), filePerm)
+	ioutil.WriteFile(mapDir+"/gm-map.svg", []byte(hexmap.SVG(t.Stars, false)), filePerm)
+	ioutil.WriteFile(mapDir+"/pc-map.svg", []byte(hexmap.SVG(t.Stars, true)), filePerm)
+
+	for file, playerMap := range map[string]bool{"/gm-map.png": false, "/pc-map.png": true} {
+		img, err := hexmap.PNG(t.Stars, playerMap, MapPixelSize)
+		if err != nil {
+			return err
+		}
+
+		ioutil.WriteFile(mapDir+file, img, filePerm)
+	}
 
-	return os.Chdir(wdir)
+	return nil
 }
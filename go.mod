@@ -8,5 +8,8 @@ require (
 	github.com/nboughton/go-roll v0.0.17
 	github.com/nboughton/go-utils v0.0.0-20200108161841-5007e997f484
 	github.com/spf13/cobra v1.2.1
+	github.com/spf13/pflag v1.0.5
 	golang.org/x/sys v0.0.0-20210630005230-0f9fa26af87c // indirect
+	golang.org/x/term v0.0.0-20201210144234-2321bbc49cbf
+	gopkg.in/yaml.v2 v2.4.0
 )
@@ -3,6 +3,7 @@ package haxscii
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 
@@ -15,16 +16,41 @@ var (
 	offset = 5
 )
 
-// Colour funcs
-var (
-	White   = color.WhiteString
-	Red     = color.RedString
-	Yellow  = color.YellowString
-	Magenta = color.MagentaString
-	Green   = color.GreenString
-	Blue    = color.BlueString
-	Cyan    = color.CyanString
-)
+// Palette is the set of colour funcs that text is drawn with. Each Palette decides for itself
+// whether to colour text so that maps can be drawn with and without colour at the same time.
+type Palette struct {
+	White   colourFunc
+	Red     colourFunc
+	Yellow  colourFunc
+	Magenta colourFunc
+	Green   colourFunc
+	Blue    colourFunc
+	Cyan    colourFunc
+}
+
+// NewPalette returns a Palette that colours text, or leaves it plain if colour is false
+func NewPalette(colour bool) Palette {
+	fn := func(a color.Attribute) colourFunc {
+		c := color.New(a)
+		if colour {
+			c.EnableColor()
+		} else {
+			c.DisableColor()
+		}
+
+		return c.SprintfFunc()
+	}
+
+	return Palette{
+		White:   fn(color.FgWhite),
+		Red:     fn(color.FgRed),
+		Yellow:  fn(color.FgYellow),
+		Magenta: fn(color.FgMagenta),
+		Green:   fn(color.FgGreen),
+		Blue:    fn(color.FgBlue),
+		Cyan:    fn(color.FgCyan),
+	}
+}
 
 func genCrdText(row, col int) string {
 	rStr, cStr := strconv.Itoa(row), strconv.Itoa(col)
@@ -166,6 +192,62 @@ func (m Map) SetTxt(row, col int, lines [4]string, color colourFunc) {
 	}
 }
 
+// SetMarker writes a short marker, such as a faction marker, to the right of the coordinates of a hex
+func (m Map) SetMarker(row, col int, marker string, colour colourFunc) {
+	var (
+		cl    = newCell(0, 0)
+		wDiff = (cl.widthMid - cl.widthTop) / 2
+		crds  = len(genCrdText(row, col)) + 1 // Leave a space after the coordinates
+		r     = row*(cl.height-1) + cl.crdsRow
+		c     = col*(cl.widthMid-wDiff) + cl.crdsCol + crds
+	)
+
+	if col%2 != 0 {
+		r += cl.height / 2
+	}
+
+	if len(marker) > cl.widthTop-crds {
+		marker = marker[:cl.widthTop-crds]
+	}
+
+	m.print(r, c, marker, colour)
+}
+
+// Line draws a dotted line between the centres of two hexes. Only empty space is drawn over so that
+// hex borders and text remain legible.
+func (m Map) Line(row1, col1, row2, col2 int, colour colourFunc) {
+	var (
+		r1, c1 = centre(row1, col1)
+		r2, c2 = centre(row2, col2)
+		steps  = math.Max(math.Abs(float64(r2-r1)), math.Abs(float64(c2-c1)))
+	)
+
+	for i := 0.0; i <= steps; i++ {
+		r := r1 + int(math.Round(float64(r2-r1)*i/steps))
+		c := c1 + int(math.Round(float64(c2-c1)*i/steps))
+
+		if r >= 0 && r < len(m) && c >= 0 && c < len(m[r]) && m[r][c] == " " {
+			m[r][c] = colour(".")
+		}
+	}
+}
+
+// centre returns the position in the Map matrix of the middle of the hex at row, col
+func centre(row, col int) (int, int) {
+	var (
+		cl    = newCell(0, 0)
+		wDiff = (cl.widthMid - cl.widthTop) / 2
+		r     = row*(cl.height-1) + cl.height/2
+		c     = col*(cl.widthMid-wDiff) + cl.widthMid/2
+	)
+
+	if col%2 != 0 {
+		r += cl.height / 2
+	}
+
+	return r, c
+}
+
 func (m Map) print(startRow, startCol int, text string, colour colourFunc) {
 	for row, col, i := startRow, startCol, 0; i < len(text); col, i = col+1, i+1 {
 		if col < 0 {
@@ -189,8 +271,3 @@ func (m Map) String() string {
 
 	return s
 }
-
-// Colour toggles the colour output on/off (true/false)
-func Colour(b bool) {
-	color.NoColor = !b
-}
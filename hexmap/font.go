@@ -0,0 +1,120 @@
+package hexmap
+
+// A minimal 5x7 bitmap font used to label raster maps without pulling in a font rendering library.
+// Each glyph is 7 rows of 5 columns where '#' is a set pixel.
+
+const (
+	glyphW = 5
+	glyphH = 7
+)
+
+// fallback is used for any rune that isn't in the font
+var fallback = [glyphH]string{".###.", "#...#", "....#", "...#.", "..#..", ".....", "..#.."}
+
+var font = map[rune][glyphH]string{
+	' ':  {".....", ".....", ".....", ".....", ".....", ".....", "....."},
+	'!':  {"..#..", "..#..", "..#..", "..#..", "..#..", ".....", "..#.."},
+	'"':  {".#.#.", ".#.#.", ".#.#.", ".....", ".....", ".....", "....."},
+	'#':  {".#.#.", ".#.#.", "#####", ".#.#.", "#####", ".#.#.", ".#.#."},
+	'$':  {"..#..", ".####", "#.#..", ".###.", "..#.#", "####.", "..#.."},
+	'%':  {"##...", "##..#", "...#.", "..#..", ".#...", "#..##", "...##"},
+	'&':  {".##..", "#..#.", "#.#..", ".#...", "#.#.#", "#..#.", ".##.#"},
+	'\'': {"..#..", "..#..", ".#...", ".....", ".....", ".....", "....."},
+	'’':  {"..#..", "..#..", ".#...", ".....", ".....", ".....", "....."},
+	'(':  {"...#.", "..#..", ".#...", ".#...", ".#...", "..#..", "...#."},
+	')':  {".#...", "..#..", "...#.", "...#.", "...#.", "..#..", ".#..."},
+	'*':  {".....", "..#..", "#.#.#", ".###.", "#.#.#", "..#..", "....."},
+	'+':  {".....", "..#..", "..#..", "#####", "..#..", "..#..", "....."},
+	',':  {".....", ".....", ".....", ".....", ".##..", "..#..", ".#..."},
+	'-':  {".....", ".....", ".....", "#####", ".....", ".....", "....."},
+	'.':  {".....", ".....", ".....", ".....", ".....", ".##..", ".##.."},
+	'/':  {".....", "....#", "...#.", "..#..", ".#...", "#....", "....."},
+	'0':  {".###.", "#...#", "#..##", "#.#.#", "##..#", "#...#", ".###."},
+	'1':  {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'2':  {".###.", "#...#", "....#", "...#.", "..#..", ".#...", "#####"},
+	'3':  {"#####", "...#.", "..#..", "...#.", "....#", "#...#", ".###."},
+	'4':  {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+	'5':  {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+	'6':  {"..##.", ".#...", "#....", "####.", "#...#", "#...#", ".###."},
+	'7':  {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+	'8':  {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	'9':  {".###.", "#...#", "#...#", ".####", "....#", "...#.", ".##.."},
+	':':  {".....", ".##..", ".##..", ".....", ".##..", ".##..", "....."},
+	';':  {".....", ".##..", ".##..", ".....", ".##..", "..#..", ".#..."},
+	'<':  {"...#.", "..#..", ".#...", "#....", ".#...", "..#..", "...#."},
+	'=':  {".....", ".....", "#####", ".....", "#####", ".....", "....."},
+	'>':  {".#...", "..#..", "...#.", "....#", "...#.", "..#..", ".#..."},
+	'?':  fallback,
+	'@':  {".###.", "#...#", "....#", ".##.#", "#.#.#", "#.#.#", ".###."},
+	'A':  {".###.", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'B':  {"####.", "#...#", "#...#", "####.", "#...#", "#...#", "####."},
+	'C':  {".###.", "#...#", "#....", "#....", "#....", "#...#", ".###."},
+	'D':  {"###..", "#..#.", "#...#", "#...#", "#...#", "#..#.", "###.."},
+	'E':  {"#####", "#....", "#....", "####.", "#....", "#....", "#####"},
+	'F':  {"#####", "#....", "#....", "####.", "#....", "#....", "#...."},
+	'G':  {".###.", "#...#", "#....", "#.###", "#...#", "#...#", ".####"},
+	'H':  {"#...#", "#...#", "#...#", "#####", "#...#", "#...#", "#...#"},
+	'I':  {".###.", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'J':  {"..###", "...#.", "...#.", "...#.", "...#.", "#..#.", ".##.."},
+	'K':  {"#...#", "#..#.", "#.#..", "##...", "#.#..", "#..#.", "#...#"},
+	'L':  {"#....", "#....", "#....", "#....", "#....", "#....", "#####"},
+	'M':  {"#...#", "##.##", "#.#.#", "#.#.#", "#...#", "#...#", "#...#"},
+	'N':  {"#...#", "#...#", "##..#", "#.#.#", "#..##", "#...#", "#...#"},
+	'O':  {".###.", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'P':  {"####.", "#...#", "#...#", "####.", "#....", "#....", "#...."},
+	'Q':  {".###.", "#...#", "#...#", "#...#", "#.#.#", "#..#.", ".##.#"},
+	'R':  {"####.", "#...#", "#...#", "####.", "#.#..", "#..#.", "#...#"},
+	'S':  {".####", "#....", "#....", ".###.", "....#", "....#", "####."},
+	'T':  {"#####", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'U':  {"#...#", "#...#", "#...#", "#...#", "#...#", "#...#", ".###."},
+	'V':  {"#...#", "#...#", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'W':  {"#...#", "#...#", "#...#", "#.#.#", "#.#.#", "#.#.#", ".#.#."},
+	'X':  {"#...#", "#...#", ".#.#.", "..#..", ".#.#.", "#...#", "#...#"},
+	'Y':  {"#...#", "#...#", "#...#", ".#.#.", "..#..", "..#..", "..#.."},
+	'Z':  {"#####", "....#", "...#.", "..#..", ".#...", "#....", "#####"},
+	'[':  {".###.", ".#...", ".#...", ".#...", ".#...", ".#...", ".###."},
+	'\\': {".....", "#....", ".#...", "..#..", "...#.", "....#", "....."},
+	']':  {".###.", "...#.", "...#.", "...#.", "...#.", "...#.", ".###."},
+	'^':  {"..#..", ".#.#.", "#...#", ".....", ".....", ".....", "....."},
+	'_':  {".....", ".....", ".....", ".....", ".....", ".....", "#####"},
+	'`':  {".#...", "..#..", "...#.", ".....", ".....", ".....", "....."},
+	'a':  {".....", ".....", ".###.", "....#", ".####", "#...#", ".####"},
+	'b':  {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "####."},
+	'c':  {".....", ".....", ".###.", "#....", "#....", "#...#", ".###."},
+	'd':  {"....#", "....#", ".##.#", "#..##", "#...#", "#...#", ".####"},
+	'e':  {".....", ".....", ".###.", "#...#", "#####", "#....", ".###."},
+	'f':  {"..##.", ".#..#", ".#...", "###..", ".#...", ".#...", ".#..."},
+	'g':  {".....", ".####", "#...#", "#...#", ".####", "....#", ".###."},
+	'h':  {"#....", "#....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+	'i':  {"..#..", ".....", ".##..", "..#..", "..#..", "..#..", ".###."},
+	'j':  {"...#.", ".....", "..##.", "...#.", "...#.", "#..#.", ".##.."},
+	'k':  {"#....", "#....", "#..#.", "#.#..", "##...", "#.#..", "#..#."},
+	'l':  {".##..", "..#..", "..#..", "..#..", "..#..", "..#..", ".###."},
+	'm':  {".....", ".....", "##.#.", "#.#.#", "#.#.#", "#...#", "#...#"},
+	'n':  {".....", ".....", "#.##.", "##..#", "#...#", "#...#", "#...#"},
+	'o':  {".....", ".....", ".###.", "#...#", "#...#", "#...#", ".###."},
+	'p':  {".....", ".....", "####.", "#...#", "####.", "#....", "#...."},
+	'q':  {".....", ".....", ".##.#", "#..##", ".####", "....#", "....#"},
+	'r':  {".....", ".....", "#.##.", "##..#", "#....", "#....", "#...."},
+	's':  {".....", ".....", ".###.", "#....", ".###.", "....#", "####."},
+	't':  {".#...", ".#...", "###..", ".#...", ".#...", ".#..#", "..##."},
+	'u':  {".....", ".....", "#...#", "#...#", "#...#", "#..##", ".##.#"},
+	'v':  {".....", ".....", "#...#", "#...#", "#...#", ".#.#.", "..#.."},
+	'w':  {".....", ".....", "#...#", "#...#", "#.#.#", "#.#.#", ".#.#."},
+	'x':  {".....", ".....", "#...#", ".#.#.", "..#..", ".#.#.", "#...#"},
+	'y':  {".....", ".....", "#...#", "#...#", ".####", "....#", ".###."},
+	'z':  {".....", ".....", "#####", "...#.", "..#..", ".#...", "#####"},
+	'{':  {"...#.", "..#..", "..#..", ".#...", "..#..", "..#..", "...#."},
+	'|':  {"..#..", "..#..", "..#..", "..#..", "..#..", "..#..", "..#.."},
+	'}':  {".#...", "..#..", "..#..", "...#.", "..#..", "..#..", ".#..."},
+	'~':  {".....", ".....", ".#...", "#.#.#", "...#.", ".....", "....."},
+}
+
+// glyph returns the bitmap for r
+func glyph(r rune) [glyphH]string {
+	if g, ok := font[r]; ok {
+		return g
+	}
+
+	return fallback
+}
@@ -0,0 +1,141 @@
+// Package hexmap renders sector maps as SVG and PNG images using the same hex layout as haxscii:
+// flat topped hexes with odd columns shifted down by half a hex.
+package hexmap
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/nboughton/swnt/content/sector"
+)
+
+var sqrt3 = math.Sqrt(3)
+
+// Colours of the map background and grid
+const (
+	background = "#101010"
+	grid       = "#505050"
+	coords     = "#808080"
+)
+
+// Colour is a colour that map text is drawn in, as #rrggbb. The ASCII map uses the ANSI colour of
+// the same name.
+type Colour string
+
+// Colours used for text on the map
+const (
+	White   Colour = "#e5e5e5"
+	Red     Colour = "#cd3131"
+	Yellow  Colour = "#e5e510"
+	Magenta Colour = "#bc3fbc"
+	Green   Colour = "#0dbc79"
+	Blue    Colour = "#2472c8"
+	Cyan    Colour = "#11a8cd"
+)
+
+// TLColour returns the colour that systems of tech level tl are drawn in on every map
+func TLColour(tl string) Colour {
+	switch tl {
+	case "TL1":
+		return Red
+	case "TL2":
+		return Yellow
+	case "TL3":
+		return Magenta
+	case "TL4", "TL4+":
+		return Green
+	case "TL5":
+		return Cyan
+	}
+
+	return White
+}
+
+// layout holds the dimensions of a map drawn with hexes of radius r
+type layout struct {
+	r          float64
+	rows, cols int
+}
+
+// size returns the width and height of the whole map
+func (l layout) size() (float64, float64) {
+	w := 1.5*l.r*float64(l.cols) + 0.5*l.r
+	h := sqrt3 * l.r * float64(l.rows)
+	if l.cols > 1 {
+		h += sqrt3 * l.r / 2
+	}
+
+	return w, h
+}
+
+// centre returns the position of the centre of the hex at row, col
+func (l layout) centre(row, col int) (float64, float64) {
+	x := l.r + 1.5*l.r*float64(col)
+	y := sqrt3*l.r/2 + sqrt3*l.r*float64(row)
+	if col%2 != 0 {
+		y += sqrt3 * l.r / 2
+	}
+
+	return x, y
+}
+
+// corners returns the 6 vertices of the hex at row, col starting with the right hand point
+func (l layout) corners(row, col int) [6][2]float64 {
+	var (
+		cx, cy = l.centre(row, col)
+		pts    [6][2]float64
+	)
+
+	for i := range pts {
+		a := math.Pi / 3 * float64(i)
+		pts[i] = [2]float64{cx + l.r*math.Cos(a), cy + l.r*math.Sin(a)}
+	}
+
+	return pts
+}
+
+// lanes returns the end points of each lane in the sector
+func lanes(data *sector.Stars) [][2]sector.Hex {
+	var out [][2]sector.Hex
+
+	for _, l := range data.Lanes {
+		from, err1 := data.Find(l.From)
+		to, err2 := data.Find(l.To)
+		if err1 != nil || err2 != nil {
+			continue
+		}
+
+		out = append(out, [2]sector.Hex{from.Hex(), to.Hex()})
+	}
+
+	return out
+}
+
+// hexText returns the lines of text displayed in a systems hex and its colour
+func hexText(s *sector.Star, playerMap bool) ([4]string, Colour) {
+	w := s.Worlds[0]
+	tl := w.TechLevel.Code
+
+	if playerMap {
+		return [4]string{s.Name, "", "", ""}, TLColour(tl)
+	}
+
+	return [4]string{s.Name, w.Tags[0].Name, w.Tags[1].Name, tl}, TLColour(tl)
+}
+
+// crdText formats hex coordinates the same way haxscii does
+func crdText(row, col int) string {
+	return fmt.Sprintf("%02d,%02d", row, col)
+}
+
+// Size returns the width and height, in pixels, of a map of rows and cols drawn with hexes that are
+// size pixels in radius (i.e a PNG map)
+func Size(rows, cols, size int) (float64, float64) {
+	return layout{r: float64(size), rows: rows, cols: cols}.size()
+}
+
+// Centre returns the position, in pixels, of the centre of the hex at row, col on a map drawn with
+// hexes that are size pixels in radius
+func Centre(row, col, size int) (float64, float64) {
+	return layout{r: float64(size)}.centre(row, col)
+}
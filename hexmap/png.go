@@ -0,0 +1,149 @@
+package hexmap
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"math"
+	"strconv"
+
+	"github.com/nboughton/swnt/content/sector"
+)
+
+// MinPixelSize is the smallest hex radius that leaves room for a hex's text
+const MinPixelSize = 40
+
+// PNG returns a PNG image of the sector map drawn with hexes that are size pixels in radius. If
+// playerMap is true only system names are shown.
+func PNG(data *sector.Stars, playerMap bool, size int) ([]byte, error) {
+	if size < MinPixelSize {
+		return nil, fmt.Errorf("hex size must be at least %d pixels", MinPixelSize)
+	}
+
+	var (
+		l     = layout{r: float64(size), rows: data.Rows, cols: data.Cols}
+		w, h  = l.size()
+		img   = image.NewRGBA(image.Rect(0, 0, int(math.Ceil(w))+1, int(math.Ceil(h))+1))
+		scale = size / 60 // Text is scaled up in whole pixels as the hexes get larger
+	)
+
+	if scale < 1 {
+		scale = 1
+	}
+
+	draw.Draw(img, img.Bounds(), &image.Uniform{rgb(background)}, image.Point{}, draw.Src)
+
+	// Grid and coordinates
+	for row := 0; row < data.Rows; row++ {
+		for col := 0; col < data.Cols; col++ {
+			pts := l.corners(row, col)
+			for i := range pts {
+				next := pts[(i+1)%len(pts)]
+				line(img, pts[i][0], pts[i][1], next[0], next[1], rgb(grid))
+			}
+
+			x, y := l.centre(row, col)
+			text(img, x, y-l.r*0.62, crdText(row, col), l.r, scale, rgb(coords))
+		}
+	}
+
+	// Lanes
+	if !playerMap {
+		for _, ends := range lanes(data) {
+			x1, y1 := l.centre(ends[0].Row, ends[0].Col)
+			x2, y2 := l.centre(ends[1].Row, ends[1].Col)
+			line(img, x1, y1-l.r*0.32, x2, y2-l.r*0.32, rgb(string(Blue)))
+		}
+	}
+
+	// Systems
+	for _, s := range data.Systems {
+		var (
+			x, y          = l.centre(s.Row, s.Col)
+			lines, colour = hexText(s, playerMap)
+			c             = rgb(string(colour))
+		)
+
+		disc(img, x, y-l.r*0.32, l.r*0.07, c)
+		text(img, x, y-l.r*0.02, lines[0], l.r, scale, c)
+		text(img, x, y+l.r*0.2, lines[1], l.r, scale, c)
+		text(img, x, y+l.r*0.38, lines[2], l.r, scale, c)
+		text(img, x, y+l.r*0.56, lines[3], l.r, scale, c)
+
+		if m := data.FactionMarker(s); m != "" && !playerMap {
+			text(img, x+l.r*0.48, y-l.r*0.62, m, l.r, scale, rgb(string(Red)))
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// rgb converts a #rrggbb string to a colour
+func rgb(hex string) color.RGBA {
+	n, _ := strconv.ParseUint(hex[1:], 16, 32)
+	return color.RGBA{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n), A: 0xff}
+}
+
+// line draws a 1 pixel line from x0, y0 to x1, y1
+func line(img *image.RGBA, x0, y0, x1, y1 float64, c color.Color) {
+	steps := math.Max(math.Abs(x1-x0), math.Abs(y1-y0))
+	for i := 0.0; i <= steps; i++ {
+		t := i / steps
+		img.Set(int(math.Round(x0+(x1-x0)*t)), int(math.Round(y0+(y1-y0)*t)), c)
+	}
+}
+
+// disc draws a filled circle of radius r centred on x, y
+func disc(img *image.RGBA, x, y, r float64, c color.Color) {
+	for py := int(y - r); py <= int(y+r); py++ {
+		for px := int(x - r); px <= int(x+r); px++ {
+			if math.Hypot(float64(px)-x, float64(py)-y) <= r {
+				img.Set(px, py, c)
+			}
+		}
+	}
+}
+
+// text draws s centred on x, y using the bitmap font. Text that would overflow the width of a
+// hex of radius r is truncated.
+func text(img *image.RGBA, x, y float64, s string, r float64, scale int, c color.Color) {
+	var (
+		runes    = []rune(s)
+		advance  = (glyphW + 1) * scale
+		maxChars = int(r*1.6) / advance
+	)
+
+	if len(runes) > maxChars {
+		runes = runes[:maxChars]
+	}
+
+	var (
+		width = len(runes)*advance - scale
+		x0    = int(x) - width/2
+		y0    = int(y) - glyphH*scale/2
+	)
+
+	for i, ch := range runes {
+		for gy, bits := range glyph(ch) {
+			for gx, bit := range bits {
+				if bit != '#' {
+					continue
+				}
+
+				for sy := 0; sy < scale; sy++ {
+					for sx := 0; sx < scale; sx++ {
+						img.Set(x0+i*advance+gx*scale+sx, y0+gy*scale+sy, c)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package hexmap
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/nboughton/swnt/content/sector"
+)
+
+// svgRadius is the radius of each hex in SVG user units. As the output is scalable this only
+// determines the proportions of text to hexes.
+const svgRadius = 60.0
+
+// SVG returns an SVG image of the sector map. If playerMap is true only system names are shown.
+func SVG(data *sector.Stars, playerMap bool) string {
+	var (
+		buf  = new(bytes.Buffer)
+		l    = layout{r: svgRadius, rows: data.Rows, cols: data.Cols}
+		w, h = l.size()
+	)
+
+	fmt.Fprintf(buf, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 %.1f %.1f" width="%.0f" height="%.0f" font-family="monospace">`+"\n", w, h, w, h)
+	fmt.Fprintf(buf, `<rect width="100%%" height="100%%" fill="%s"/>`+"\n", background)
+
+	// Grid and coordinates
+	fmt.Fprintf(buf, `<g fill="none" stroke="%s" stroke-width="1.5">`+"\n", grid)
+	for row := 0; row < data.Rows; row++ {
+		for col := 0; col < data.Cols; col++ {
+			var pts []string
+			for _, p := range l.corners(row, col) {
+				pts = append(pts, fmt.Sprintf("%.1f,%.1f", p[0], p[1]))
+			}
+			fmt.Fprintf(buf, `<polygon points="%s"/>`+"\n", strings.Join(pts, " "))
+		}
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	fmt.Fprintf(buf, `<g fill="%s" font-size="%.1f" text-anchor="middle">`+"\n", coords, l.r*0.18)
+	for row := 0; row < data.Rows; row++ {
+		for col := 0; col < data.Cols; col++ {
+			x, y := l.centre(row, col)
+			fmt.Fprintf(buf, `<text x="%.1f" y="%.1f">%s</text>`+"\n", x, y-l.r*0.6, crdText(row, col))
+		}
+	}
+	fmt.Fprintln(buf, "</g>")
+
+	// Lanes
+	if !playerMap {
+		fmt.Fprintf(buf, `<g stroke="%s" stroke-width="3" stroke-dasharray="8 6">`+"\n", Blue)
+		for _, ends := range lanes(data) {
+			x1, y1 := l.centre(ends[0].Row, ends[0].Col)
+			x2, y2 := l.centre(ends[1].Row, ends[1].Col)
+			fmt.Fprintf(buf, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f"/>`+"\n", x1, y1-l.r*0.3, x2, y2-l.r*0.3)
+		}
+		fmt.Fprintln(buf, "</g>")
+	}
+
+	// Systems
+	for _, s := range data.Systems {
+		var (
+			x, y          = l.centre(s.Row, s.Col)
+			lines, colour = hexText(s, playerMap)
+		)
+
+		fmt.Fprintf(buf, `<g fill="%s" text-anchor="middle">`+"\n", colour)
+		fmt.Fprintf(buf, `<circle cx="%.1f" cy="%.1f" r="%.1f"/>`+"\n", x, y-l.r*0.3, l.r*0.07)
+		svgText(buf, x, y+l.r*0.05, l.r*0.2, lines[0], `font-weight="bold"`)
+		svgText(buf, x, y+l.r*0.28, l.r*0.15, lines[1], "")
+		svgText(buf, x, y+l.r*0.46, l.r*0.15, lines[2], "")
+		svgText(buf, x, y+l.r*0.66, l.r*0.15, lines[3], "")
+		fmt.Fprintln(buf, "</g>")
+
+		if m := data.FactionMarker(s); m != "" && !playerMap {
+			fmt.Fprintf(buf, `<text x="%.1f" y="%.1f" font-size="%.1f" font-weight="bold" fill="%s">%s</text>`+"\n", x+l.r*0.3, y-l.r*0.6, l.r*0.18, Red, m)
+		}
+	}
+
+	fmt.Fprintln(buf, "</svg>")
+
+	return buf.String()
+}
+
+// svgText writes a text element centred on x, compressing it to fit the width of a hex if necessary
+func svgText(buf *bytes.Buffer, x, y, size float64, text, attrs string) {
+	if text == "" {
+		return
+	}
+
+	var (
+		maxWidth = svgRadius * 1.6
+		estWidth = float64(len(text)) * size * 0.6 // Monospace glyphs are roughly 0.6em wide
+	)
+
+	if estWidth > maxWidth {
+		attrs += fmt.Sprintf(` textLength="%.1f" lengthAdjust="spacingAndGlyphs"`, maxWidth)
+	}
+
+	fmt.Fprintf(buf, `<text x="%.1f" y="%.1f" font-size="%.1f" %s>%s</text>`+"\n", x, y, size, strings.TrimSpace(attrs), html.EscapeString(text))
+}